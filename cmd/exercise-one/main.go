@@ -0,0 +1,15 @@
+package main
+
+import (
+  "log"
+  "os"
+
+  "github.com/lollar/gotour/lesson"
+  _ "github.com/lollar/gotour/lessons/exercises"
+)
+
+func main() {
+  if err := lesson.Run(os.Stdout, "exercises/loops"); err != nil {
+    log.Fatal(err)
+  }
+}
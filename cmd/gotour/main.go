@@ -0,0 +1,85 @@
+// Command gotour lists and runs the sections of every lesson.
+//
+//   gotour list
+//   gotour run moretypes/sliceOfSlices flowcontrol
+//   gotour run --all
+package main
+
+import (
+  "flag"
+  "fmt"
+  "os"
+
+  "github.com/lollar/gotour/lesson"
+  _ "github.com/lollar/gotour/lessons/exercises"
+  _ "github.com/lollar/gotour/lessons/flowcontrol"
+  _ "github.com/lollar/gotour/lessons/methods"
+  _ "github.com/lollar/gotour/lessons/moretypes"
+  _ "github.com/lollar/gotour/lessons/tour"
+)
+
+func usage() {
+  fmt.Fprintln(os.Stderr, "usage: gotour list")
+  fmt.Fprintln(os.Stderr, "       gotour run <lesson>[/<section>]...")
+  fmt.Fprintln(os.Stderr, "       gotour run --all")
+  os.Exit(2)
+}
+
+func list() {
+  for _, l := range lesson.All() {
+    for _, s := range l.Sections {
+      fmt.Printf("%s/%s\n", l.Name, s.Name)
+    }
+  }
+}
+
+func run(args []string) {
+  fs := flag.NewFlagSet("run", flag.ExitOnError)
+  all := fs.Bool("all", false, "run every section of every lesson")
+  fs.Usage = usage
+  fs.Parse(args)
+
+  paths := fs.Args()
+  if *all {
+    paths = nil
+    for _, l := range lesson.All() {
+      paths = append(paths, l.Name)
+    }
+  }
+  if len(paths) == 0 {
+    usage()
+  }
+
+  var sections []lesson.Section
+  for _, path := range paths {
+    resolved, err := lesson.Resolve(path)
+    if err != nil {
+      fmt.Fprintln(os.Stderr, "gotour:", err)
+      os.Exit(1)
+    }
+    sections = append(sections, resolved...)
+  }
+
+  // Only label output when more than one section is being run.
+  for _, s := range sections {
+    if len(sections) > 1 {
+      fmt.Printf("== %s\n", s.Path())
+    }
+    s.Run(os.Stdout)
+  }
+}
+
+func main() {
+  if len(os.Args) < 2 {
+    usage()
+  }
+
+  switch os.Args[1] {
+  case "list":
+    list()
+  case "run":
+    run(os.Args[2:])
+  default:
+    usage()
+  }
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+  "log"
+  "os"
+
+  "github.com/lollar/gotour/lesson"
+  _ "github.com/lollar/gotour/lessons/moretypes"
+)
+
+func main() {
+  if err := lesson.Run(os.Stdout, "moretypes"); err != nil {
+    log.Fatal(err)
+  }
+}
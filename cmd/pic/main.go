@@ -0,0 +1,115 @@
+// Command pic renders a Pic pattern to an image file.
+//
+//   pic -w 256 -h 256 -pattern shift -o out.png
+//
+// The format is taken from the output file's extension unless -format is
+// given; an extension that is not one of the supported formats is an error.
+// Without -o the image is written to standard output as PNG.
+//
+// With -preview the picture is drawn on the terminal instead, shrunk to
+// fit its width and height, in 24-bit color or, with -ascii, as plain
+// text. Giving -o as well writes the image file too.
+//
+//   pic -pattern mandelbrot -w 512 -h 512 -preview
+package main
+
+import (
+  "flag"
+  "fmt"
+  "os"
+  "slices"
+  "strings"
+
+  "github.com/lollar/gotour/picture"
+)
+
+func main() {
+  var (
+    width   = flag.Int("w", 256, "image `width` in pixels")
+    height  = flag.Int("h", 256, "image `height` in pixels")
+    pattern = flag.String("pattern", "shift", "`name` of the pattern to draw: "+strings.Join(picture.Patterns(), ", "))
+    out     = flag.String("o", "", "output `file`; standard output if empty")
+    format  = flag.String("format", "", "image `format`: "+strings.Join(picture.Formats, ", ")+"; defaults to the -o extension, or png")
+    workers = flag.Int("workers", 0, "number of goroutines rendering tiles; 0 uses GOMAXPROCS")
+    preview = flag.Bool("preview", false, "draw the picture on the terminal")
+    ascii   = flag.Bool("ascii", false, "with -preview, draw plain text instead of color")
+  )
+  flag.Usage = func() {
+    fmt.Fprintln(os.Stderr, "usage: pic [-w width] [-h height] [-pattern name] [-format format] [-workers n] [-preview [-ascii]] [-o file]")
+    flag.PrintDefaults()
+  }
+  flag.Parse()
+
+  if flag.NArg() > 0 || *width <= 0 || *height <= 0 {
+    flag.Usage()
+    os.Exit(2)
+  }
+
+  p, ok := picture.Lookup(*pattern)
+  if !ok {
+    fatalf("unknown pattern %q", *pattern)
+  }
+
+  // Check the format now rather than after rendering and creating the file.
+  switch {
+  case *format != "":
+    if !slices.Contains(picture.Formats, *format) {
+      usagef("unknown image format %q", *format)
+    }
+  case *out != "":
+    if *format = picture.FormatOf(*out); *format == "" {
+      usagef("unknown image format for %s; use -format", *out)
+    }
+  default:
+    *format = "png"
+  }
+
+  data := picture.Renderer{Workers: *workers}.Render(p(*width, *height), *width, *height)
+  if *preview {
+    var err error
+    if *ascii {
+      err = picture.WriteASCII(os.Stdout, data, terminalWidth(), terminalHeight())
+    } else {
+      err = picture.WriteANSI(os.Stdout, data, picture.GrayPalette, terminalWidth(), terminalHeight())
+    }
+    if err != nil {
+      fatalf("%v", err)
+    }
+    if *out == "" {
+      return
+    }
+  }
+  if err := write(*out, data, *format); err != nil {
+    fatalf("%v", err)
+  }
+}
+
+// write encodes data to the file at path, or to standard output if path
+// is empty.
+func write(path string, data [][]uint8, format string) error {
+  if path == "" {
+    return picture.Encode(os.Stdout, data, format)
+  }
+
+  f, err := os.Create(path)
+  if err != nil {
+    return err
+  }
+  if err := picture.Encode(f, data, format); err != nil {
+    f.Close()
+    return err
+  }
+  return f.Close()
+}
+
+// usagef reports a problem with the command line and exits with status 2.
+func usagef(format string, args ...any) {
+  fmt.Fprintf(os.Stderr, "pic: "+format+"\n", args...)
+  flag.Usage()
+  os.Exit(2)
+}
+
+func fatalf(format string, args ...any) {
+  fmt.Fprintf(os.Stderr, "pic: "+format+"\n", args...)
+  os.Exit(1)
+}
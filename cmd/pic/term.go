@@ -0,0 +1,32 @@
+package main
+
+import (
+  "os"
+  "strconv"
+)
+
+// terminalWidth reports the width of the terminal in columns, trusting
+// $COLUMNS first, then asking the terminal on standard output, and
+// falling back to 80.
+func terminalWidth() int {
+  if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+    return n
+  }
+  if _, n := ttySize(os.Stdout); n > 0 {
+    return n
+  }
+  return 80
+}
+
+// terminalHeight reports the height of the terminal in lines, less one for
+// the prompt, trusting $LINES first, then asking the terminal on standard
+// output, and falling back to 24.
+func terminalHeight() int {
+  n, err := strconv.Atoi(os.Getenv("LINES"))
+  if err != nil || n <= 0 {
+    if n, _ = ttySize(os.Stdout); n <= 0 {
+      n = 24
+    }
+  }
+  return max(n-1, 1)
+}
@@ -0,0 +1,7 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package main
+
+import "os"
+
+func ttySize(f *os.File) (rows, cols int) { return 0, 0 }
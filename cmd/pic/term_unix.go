@@ -0,0 +1,20 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package main
+
+import (
+  "os"
+  "syscall"
+  "unsafe"
+)
+
+// ttySize asks the terminal behind f for its size, returning zeros if f is
+// not a terminal.
+func ttySize(f *os.File) (rows, cols int) {
+  var ws struct{ Row, Col, X, Y uint16 }
+  _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+  if errno != 0 {
+    return 0, 0
+  }
+  return int(ws.Row), int(ws.Col)
+}
@@ -0,0 +1,113 @@
+// Command sqrt prints square roots computed with Newton's method.
+//
+//   sqrt 2 3
+//   sqrt -digits 100 2
+//   sqrt -2
+//
+// Without -digits it uses float64 arithmetic; with it, each root is
+// computed with math/big and printed to that many decimal places.
+// Negative numbers are taken as operands rather than flags, and are
+// reported as having no real square root.
+package main
+
+import (
+  "errors"
+  "flag"
+  "fmt"
+  "math/big"
+  "os"
+  "slices"
+  "strconv"
+  "strings"
+
+  "github.com/lollar/gotour/roots"
+)
+
+func main() {
+  digits := flag.Int("digits", 0, "print roots to `n` decimal places using arbitrary precision")
+  flag.Usage = func() {
+    fmt.Fprintln(os.Stderr, "usage: sqrt [-digits n] x...")
+    flag.PrintDefaults()
+  }
+  flag.CommandLine.Parse(operands(os.Args[1:]))
+
+  if flag.NArg() == 0 || *digits < 0 {
+    flag.Usage()
+    os.Exit(2)
+  }
+
+  status := 0
+  for _, arg := range flag.Args() {
+    var err error
+    if *digits > 0 {
+      err = printBig(arg, *digits)
+    } else {
+      err = printFloat(arg)
+    }
+
+    if err != nil {
+      fmt.Fprintf(os.Stderr, "sqrt: %s: %v\n", arg, err)
+      status = 1
+    }
+  }
+  os.Exit(status)
+}
+
+// operands inserts "--" before a negative number among the flags, so that
+// flag does not mistake it for an unknown flag. Once flag sees an operand
+// it stops looking for flags, so later arguments need no help.
+func operands(args []string) []string {
+  for i, arg := range args {
+    if i > 0 && (args[i-1] == "-digits" || args[i-1] == "--digits") {
+      continue
+    }
+    if arg == "--" || !strings.HasPrefix(arg, "-") || arg == "-" {
+      break
+    }
+    if _, err := strconv.ParseFloat(arg, 64); err == nil || errors.Is(err, strconv.ErrRange) {
+      return slices.Insert(slices.Clone(args), i, "--")
+    }
+  }
+  return args
+}
+
+func printFloat(arg string) error {
+  x, err := strconv.ParseFloat(arg, 64)
+  if err != nil {
+    return err
+  }
+
+  z, err := roots.Sqrt(x)
+  if err != nil {
+    return err
+  }
+  fmt.Println(z)
+  return nil
+}
+
+func printBig(arg string, digits int) error {
+  // Parse once to learn the magnitude of x, then again with enough bits
+  // for the integer part of the root, the requested decimal places and a
+  // few more to round the last digit correctly.
+  x, _, err := big.ParseFloat(arg, 10, 64, big.ToNearestEven)
+  if err != nil {
+    return err
+  }
+
+  prec := roots.DigitsPrec(digits) + 16
+  if exp := x.MantExp(nil); exp > 0 {
+    prec += uint(exp/2 + 1)
+  }
+
+  x, _, err = big.ParseFloat(arg, 10, prec, big.ToNearestEven)
+  if err != nil {
+    return err
+  }
+
+  z, err := roots.BigSqrt(x, prec)
+  if err != nil {
+    return err
+  }
+  fmt.Println(z.Text('f', digits))
+  return nil
+}
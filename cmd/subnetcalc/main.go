@@ -0,0 +1,105 @@
+// Command subnetcalc describes IPv4 CIDR prefixes.
+//
+//   subnetcalc 192.168.1.0/29
+//   subnetcalc -split 26 10.0.0.0/24
+//   subnetcalc -aggregate 10.0.0.0/25 10.0.0.128/25 10.0.1.0/24
+//
+// For each prefix it prints the netmask, network and broadcast addresses
+// and host count, then lists the hosts one per line, as the Stringers
+// exercise prints its hosts map. With -split each prefix is first divided
+// into subnets of the given length; with -aggregate the prefixes are
+// merged into the fewest that cover the same addresses.
+package main
+
+import (
+  "flag"
+  "fmt"
+  "iter"
+  "os"
+  "slices"
+
+  "github.com/lollar/gotour/ipaddr"
+)
+
+func main() {
+  var (
+    split     = flag.Int("split", -1, "divide each prefix into subnets of this `length`")
+    aggregate = flag.Bool("aggregate", false, "merge the prefixes before describing them")
+    maxHosts  = flag.Uint64("max", 256, "list at most `n` hosts per prefix; 0 lists none")
+  )
+  flag.Usage = func() {
+    fmt.Fprintln(os.Stderr, "usage: subnetcalc [-split length] [-aggregate] [-max n] prefix...")
+    flag.PrintDefaults()
+  }
+  flag.Parse()
+
+  if flag.NArg() == 0 {
+    flag.Usage()
+    os.Exit(2)
+  }
+
+  var prefixes []ipaddr.IPPrefix
+  for _, arg := range flag.Args() {
+    p, err := ipaddr.ParseIPPrefix(arg)
+    if err != nil {
+      fatal(err)
+    }
+    prefixes = append(prefixes, p)
+  }
+  if *aggregate {
+    prefixes = ipaddr.Aggregate(prefixes)
+  }
+
+  var all iter.Seq[ipaddr.IPPrefix] = slices.Values(prefixes)
+  if *split >= 0 {
+    var seqs []iter.Seq[ipaddr.IPPrefix]
+    for _, p := range prefixes {
+      seq, err := p.Subnets(*split)
+      if err != nil {
+        fatal(err)
+      }
+      seqs = append(seqs, seq)
+    }
+    all = func(yield func(ipaddr.IPPrefix) bool) {
+      for _, seq := range seqs {
+        for p := range seq {
+          if !yield(p) {
+            return
+          }
+        }
+      }
+    }
+  }
+
+  first := true
+  for p := range all {
+    if !first {
+      fmt.Println()
+    }
+    first = false
+    describe(p, *maxHosts)
+  }
+}
+
+func describe(p ipaddr.IPPrefix, maxHosts uint64) {
+  fmt.Printf("prefix: %v\n", p.Masked())
+  fmt.Printf("netmask: %v\n", p.Mask())
+  fmt.Printf("network: %v\n", p.Network())
+  fmt.Printf("broadcast: %v\n", p.Broadcast())
+  fmt.Printf("hosts: %d\n", p.NumHosts())
+
+  n := uint64(0)
+  for ip := range p.Hosts() {
+    if n == maxHosts {
+      fmt.Printf("... %d more\n", p.NumHosts()-n)
+      break
+    }
+    n++
+    fmt.Printf("host%d: %v\n", n, ip)
+  }
+}
+
+func fatal(err error) {
+  fmt.Fprintln(os.Stderr, "subnetcalc:", err)
+  os.Exit(1)
+}
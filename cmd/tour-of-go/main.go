@@ -0,0 +1,15 @@
+package main
+
+import (
+  "log"
+  "os"
+
+  "github.com/lollar/gotour/lesson"
+  _ "github.com/lollar/gotour/lessons/tour"
+)
+
+func main() {
+  if err := lesson.Run(os.Stdout, "tour"); err != nil {
+    log.Fatal(err)
+  }
+}
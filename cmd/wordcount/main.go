@@ -0,0 +1,104 @@
+// Command wordcount reports the most frequent words, or runs of words, in
+// its input files, or in standard input if none are given.
+//
+//   wordcount -k 20 -fold -strip README.md docs/
+//   cat log.txt | wordcount -n 2 -format csv
+package main
+
+import (
+  "context"
+  "errors"
+  "flag"
+  "fmt"
+  "io/fs"
+  "os"
+  "path/filepath"
+  "slices"
+  "strings"
+
+  "github.com/lollar/gotour/wordcount"
+  "github.com/lollar/gotour/wordfreq"
+)
+
+func main() {
+  var (
+    k       = flag.Int("k", 10, "report the `k` most frequent entries; 0 reports all")
+    n       = flag.Int("n", 1, "count runs of `n` words: 2 for bigrams, 3 for trigrams")
+    format  = flag.String("format", "text", "report `format`: "+strings.Join(wordfreq.Formats, ", "))
+    unicode = flag.Bool("unicode", false, "split on Unicode word boundaries instead of whitespace")
+    strip   = flag.Bool("strip", false, "strip punctuation from the ends of words")
+    fold    = flag.Bool("fold", false, "ignore case")
+    stop    = flag.Bool("stop", false, "ignore common English stop words")
+    workers = flag.Int("workers", 0, "number of files to read at once; 0 uses GOMAXPROCS")
+  )
+  flag.Usage = func() {
+    fmt.Fprintln(os.Stderr, "usage: wordcount [flags] [file|dir]...")
+    flag.PrintDefaults()
+  }
+  flag.Parse()
+
+  // Check the format now rather than after reading all the input.
+  if !slices.Contains(wordfreq.Formats, *format) {
+    fmt.Fprintf(os.Stderr, "wordcount: unknown report format %q\n", *format)
+    flag.Usage()
+    os.Exit(2)
+  }
+
+  opts := []wordcount.Option{wordcount.WithNGrams(*n)}
+  if *unicode {
+    opts = append(opts, wordcount.WithUnicodeWords())
+  }
+  if *strip {
+    opts = append(opts, wordcount.WithPunctuationStripped())
+  }
+  if *fold {
+    opts = append(opts, wordcount.WithCaseFolding())
+  }
+  if *stop {
+    opts = append(opts, wordcount.WithStopWords(wordcount.EnglishStopWords...))
+  }
+  wc := wordcount.New(opts...)
+
+  counts, err := count(wc, flag.Args(), *workers)
+  if err != nil {
+    // Report unreadable files but still print what could be counted.
+    fmt.Fprintln(os.Stderr, "wordcount:", err)
+  }
+
+  if err := wordfreq.NewReport(counts, *k).Write(os.Stdout, *format); err != nil {
+    fmt.Fprintln(os.Stderr, "wordcount:", err)
+    os.Exit(1)
+  }
+  if err != nil {
+    os.Exit(1)
+  }
+}
+
+func count(wc *wordcount.WordCounter, args []string, workers int) (map[string]int, error) {
+  if len(args) == 0 {
+    return wc.CountReader(os.Stdin)
+  }
+
+  // Expand directories so that files and directories can be mixed. A
+  // path that cannot be walked is reported as is, and the walk carries on
+  // with the rest.
+  var (
+    paths []string
+    errs  []error
+  )
+  for _, arg := range args {
+    filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+      if err != nil {
+        errs = append(errs, err)
+        return nil
+      }
+      if !d.IsDir() {
+        paths = append(paths, path)
+      }
+      return nil
+    })
+  }
+
+  counts, err := wc.CountFiles(context.Background(), paths, workers)
+  return counts, errors.Join(append(errs, err)...)
+}
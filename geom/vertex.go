@@ -0,0 +1,44 @@
+package geom
+
+import "math"
+
+// Methods
+// Go does not have classes but you can define methods on types
+// receive appears in it's own argument list
+
+// Vertex is a point in the plane.
+type Vertex struct {
+  X, Y float64
+}
+
+// Abs returns the distance of v from the origin.
+func (v Vertex) Abs() float64 {
+  return math.Sqrt(v.X * v.X + v.Y * v.Y)
+}
+
+// Pointer receivers
+// Methods can be declared with pointer recievers. Meaning the receiver type
+// has the literal syntax *T where T cannot also be a pointer.  Methods with
+// pointer receivers can modify the value. With a value receiver, a method
+// operates on a copy of the original value. Pointer receivers must be used
+// to operate on the original declared value being passed to the function
+
+// Scale multiplies both coordinates of v by f.
+func (v *Vertex) Scale(f float64) {
+  v.X = v.X * f
+  v.Y = v.Y * f
+}
+
+// Pointers and functions
+// Pointers can also be used in an argument list for a function
+
+// AbsFunc is Abs written as a plain function.
+func AbsFunc(v Vertex) float64 {
+  return math.Sqrt(v.X * v.X + v.Y * v.Y)
+}
+
+// ScaleFunc is Scale written as a plain function.
+func ScaleFunc(v *Vertex, f float64) {
+  v.X = v.X * f
+  v.Y = v.Y * f
+}
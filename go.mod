@@ -0,0 +1,3 @@
+module github.com/lollar/gotour
+
+go 1.23
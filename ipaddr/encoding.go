@@ -0,0 +1,95 @@
+package ipaddr
+
+import (
+  "database/sql"
+  "database/sql/driver"
+  "encoding"
+  "encoding/json"
+  "errors"
+  "fmt"
+)
+
+var (
+  _ encoding.TextMarshaler     = IPAddr{}
+  _ encoding.TextUnmarshaler   = (*IPAddr)(nil)
+  _ encoding.BinaryMarshaler   = IPAddr{}
+  _ encoding.BinaryUnmarshaler = (*IPAddr)(nil)
+  _ json.Marshaler             = IPAddr{}
+  _ json.Unmarshaler           = (*IPAddr)(nil)
+  _ sql.Scanner                = (*IPAddr)(nil)
+  _ driver.Valuer              = IPAddr{}
+)
+
+// MarshalText returns ip in dotted-quad notation. Being a TextMarshaler
+// also lets IPAddr be a key in maps encoded as JSON.
+func (ip IPAddr) MarshalText() ([]byte, error) {
+  return []byte(ip.String()), nil
+}
+
+// UnmarshalText sets ip from its dotted-quad notation, as ParseIPAddr
+// does.
+func (ip *IPAddr) UnmarshalText(text []byte) error {
+  v, err := ParseIPAddr(string(text))
+  if err != nil {
+    return err
+  }
+  *ip = v
+  return nil
+}
+
+// MarshalBinary returns ip's four octets in network order.
+func (ip IPAddr) MarshalBinary() ([]byte, error) {
+  return ip[:], nil
+}
+
+// UnmarshalBinary sets ip from four octets in network order.
+func (ip *IPAddr) UnmarshalBinary(data []byte) error {
+  if len(data) != len(ip) {
+    return fmt.Errorf("ipaddr: binary address has %d bytes, want 4", len(data))
+  }
+  *ip = IPAddr(data)
+  return nil
+}
+
+// MarshalJSON encodes ip as a JSON string such as "10.0.0.1".
+func (ip IPAddr) MarshalJSON() ([]byte, error) {
+  return json.Marshal(ip.String())
+}
+
+// UnmarshalJSON decodes a JSON string holding a dotted-quad address. As
+// usual for JSON, null leaves ip unchanged.
+func (ip *IPAddr) UnmarshalJSON(data []byte) error {
+  if string(data) == "null" {
+    return nil
+  }
+  var s string
+  if err := json.Unmarshal(data, &s); err != nil {
+    return fmt.Errorf("ipaddr: address must be a JSON string: %w", err)
+  }
+  return ip.UnmarshalText([]byte(s))
+}
+
+// Value stores ip in a database as its dotted-quad string.
+func (ip IPAddr) Value() (driver.Value, error) {
+  return ip.String(), nil
+}
+
+// Scan reads ip from a database column holding either a dotted-quad
+// string or the four bytes written by MarshalBinary. A NULL column is an
+// error; scan into a sql.Null[IPAddr] when the column may be NULL.
+func (ip *IPAddr) Scan(src any) error {
+  switch src := src.(type) {
+  case string:
+    return ip.UnmarshalText([]byte(src))
+  case []byte:
+    // No dotted-quad is shorter than "0.0.0.0", so four bytes must be
+    // binary.
+    if len(src) == len(ip) {
+      return ip.UnmarshalBinary(src)
+    }
+    return ip.UnmarshalText(src)
+  case nil:
+    return errors.New("ipaddr: cannot scan NULL into an IPAddr")
+  }
+  return fmt.Errorf("ipaddr: cannot scan %T into an IPAddr", src)
+}
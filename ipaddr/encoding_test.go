@@ -0,0 +1,109 @@
+package ipaddr
+
+import (
+  "database/sql"
+  "encoding/json"
+  "errors"
+  "testing"
+)
+
+func TestTextAndBinary(t *testing.T) {
+  ip := IPAddr{192, 168, 0, 1}
+
+  text, _ := ip.MarshalText()
+  var got IPAddr
+  if err := got.UnmarshalText(text); err != nil || got != ip || string(text) != "192.168.0.1" {
+    t.Errorf("text round trip via %q = %v, %v, want %v", text, got, err, ip)
+  }
+  var pe *ParseError
+  if err := got.UnmarshalText([]byte("192.168.0.256")); !errors.As(err, &pe) {
+    t.Errorf("UnmarshalText of a bad address: error = %v, want a *ParseError", err)
+  }
+
+  bin, _ := ip.MarshalBinary()
+  got = IPAddr{}
+  if err := got.UnmarshalBinary(bin); err != nil || got != ip {
+    t.Errorf("binary round trip via %v = %v, %v, want %v", bin, got, err, ip)
+  }
+  if err := got.UnmarshalBinary([]byte{1, 2, 3}); err == nil {
+    t.Errorf("UnmarshalBinary of 3 bytes succeeded")
+  }
+}
+
+func TestJSON(t *testing.T) {
+  type inventory struct {
+    Gateway IPAddr            `json:"gateway"`
+    Hosts   map[string]IPAddr `json:"hosts"`
+    Owners  map[IPAddr]string `json:"owners"`
+  }
+  in := inventory{
+    Gateway: IPAddr{10, 0, 0, 1},
+    Hosts:   map[string]IPAddr{"loopback": {127, 0, 0, 1}, "googleDNS": {8, 8, 8, 8}},
+    Owners:  map[IPAddr]string{{10, 0, 0, 1}: "netops"},
+  }
+
+  data, err := json.Marshal(in)
+  if err != nil {
+    t.Fatal(err)
+  }
+  want := `{"gateway":"10.0.0.1","hosts":{"googleDNS":"8.8.8.8","loopback":"127.0.0.1"},"owners":{"10.0.0.1":"netops"}}`
+  if string(data) != want {
+    t.Errorf("json.Marshal = %s, want %s", data, want)
+  }
+
+  var out inventory
+  if err := json.Unmarshal(data, &out); err != nil {
+    t.Fatal(err)
+  }
+  if out.Gateway != in.Gateway || out.Hosts["googleDNS"] != in.Hosts["googleDNS"] || out.Owners[IPAddr{10, 0, 0, 1}] != "netops" {
+    t.Errorf("json round trip = %+v, want %+v", out, in)
+  }
+
+  ip := IPAddr{1, 2, 3, 4}
+  if err := json.Unmarshal([]byte("null"), &ip); err != nil || ip != (IPAddr{1, 2, 3, 4}) {
+    t.Errorf("unmarshaling null = %v, %v, want the address unchanged", ip, err)
+  }
+  for _, bad := range []string{`"1.2.3"`, `[1,2,3,4]`, `16909060`} {
+    if err := json.Unmarshal([]byte(bad), &ip); err == nil {
+      t.Errorf("json.Unmarshal(%s) succeeded", bad)
+    }
+  }
+}
+
+func TestSQL(t *testing.T) {
+  v, err := IPAddr{10, 1, 2, 3}.Value()
+  if err != nil || v != "10.1.2.3" {
+    t.Errorf("Value() = %v, %v, want 10.1.2.3", v, err)
+  }
+
+  tests := []struct {
+    src  any
+    want IPAddr
+  }{
+    {"10.1.2.3", IPAddr{10, 1, 2, 3}},
+    {[]byte("10.1.2.3"), IPAddr{10, 1, 2, 3}},
+    {[]byte{10, 1, 2, 3}, IPAddr{10, 1, 2, 3}},
+  }
+  for _, tt := range tests {
+    var ip IPAddr
+    if err := ip.Scan(tt.src); err != nil || ip != tt.want {
+      t.Errorf("Scan(%#v) = %v, %v, want %v", tt.src, ip, err, tt.want)
+    }
+  }
+
+  for _, bad := range []any{nil, int64(167838211), "10.1.2", []byte{10, 1, 2}} {
+    var ip IPAddr
+    if err := ip.Scan(bad); err == nil {
+      t.Errorf("Scan(%#v) succeeded", bad)
+    }
+  }
+
+  // NULL columns go through sql.Null.
+  var n sql.Null[IPAddr]
+  if err := n.Scan(nil); err != nil || n.Valid {
+    t.Errorf("sql.Null[IPAddr].Scan(nil) = %+v, %v, want invalid", n, err)
+  }
+  if err := n.Scan("8.8.8.8"); err != nil || !n.Valid || n.V != (IPAddr{8, 8, 8, 8}) {
+    t.Errorf("sql.Null[IPAddr].Scan(8.8.8.8) = %+v, %v", n, err)
+  }
+}
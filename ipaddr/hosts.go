@@ -0,0 +1,242 @@
+package ipaddr
+
+import (
+  "bufio"
+  "cmp"
+  "errors"
+  "fmt"
+  "io"
+  "iter"
+  "os"
+  "slices"
+  "strings"
+)
+
+// Compare returns -1, 0 or +1 as a is numerically before, equal to or
+// after b.
+func Compare(a, b IPAddr) int {
+  return cmp.Compare(a.uint32(), b.uint32())
+}
+
+// Next returns the address after ip, wrapping from 255.255.255.255 to
+// 0.0.0.0.
+func (ip IPAddr) Next() IPAddr {
+  return fromUint32(ip.uint32() + 1)
+}
+
+// Prev returns the address before ip, wrapping from 0.0.0.0 to
+// 255.255.255.255.
+func (ip IPAddr) Prev() IPAddr {
+  return fromUint32(ip.uint32() - 1)
+}
+
+// IPRange is an inclusive range of addresses, such as
+// 10.0.0.1-10.0.0.50. First must not be after Last.
+type IPRange struct {
+  First, Last IPAddr
+}
+
+// ParseIPRange parses s as two addresses joined by '-', as in
+// "10.0.0.1-10.0.0.50". A single address is a range of one.
+func ParseIPRange(s string) (IPRange, error) {
+  first, last, found := strings.Cut(s, "-")
+  a, err := ParseIPAddr(first)
+  if err != nil {
+    pe := err.(*ParseError)
+    return IPRange{}, &ParseError{Input: s, Pos: pe.Pos, Msg: pe.Msg}
+  }
+  if !found {
+    return IPRange{First: a, Last: a}, nil
+  }
+
+  b, err := ParseIPAddr(last)
+  if err != nil {
+    pe := err.(*ParseError)
+    return IPRange{}, &ParseError{Input: s, Pos: len(first) + 1 + pe.Pos, Msg: pe.Msg}
+  }
+  if Compare(a, b) > 0 {
+    return IPRange{}, &ParseError{Input: s, Pos: len(first) + 1, Msg: "range ends before it starts"}
+  }
+  return IPRange{First: a, Last: b}, nil
+}
+
+// String formats r as "first-last".
+func (r IPRange) String() string {
+  return r.First.String() + "-" + r.Last.String()
+}
+
+// Contains reports whether ip is in r.
+func (r IPRange) Contains(ip IPAddr) bool {
+  return Compare(r.First, ip) <= 0 && Compare(ip, r.Last) <= 0
+}
+
+// Len returns how many addresses r holds.
+func (r IPRange) Len() uint64 {
+  return uint64(r.Last.uint32()-r.First.uint32()) + 1
+}
+
+// All yields the addresses in r in ascending order.
+func (r IPRange) All() iter.Seq[IPAddr] {
+  return func(yield func(IPAddr) bool) {
+    for ip := r.First; ; ip = ip.Next() {
+      if !yield(ip) || ip == r.Last {
+        return
+      }
+    }
+  }
+}
+
+// HostTable maps host names to addresses and back. Unlike ranging over a
+// map, its iterators visit hosts in a fixed order. Names are compared
+// exactly, so "Gateway" and "gateway" are different hosts. The zero value
+// is an empty table ready to use.
+type HostTable struct {
+  byName map[string]IPAddr
+  byAddr map[IPAddr][]string // sorted
+}
+
+// Set maps name to ip, replacing any address name had before.
+func (t *HostTable) Set(name string, ip IPAddr) {
+  if t.byName == nil {
+    t.byName = make(map[string]IPAddr)
+    t.byAddr = make(map[IPAddr][]string)
+  }
+  t.Delete(name)
+  t.byName[name] = ip
+
+  names := t.byAddr[ip]
+  i, _ := slices.BinarySearch(names, name)
+  t.byAddr[ip] = slices.Insert(names, i, name)
+}
+
+// Delete removes name from t, if it is there.
+func (t *HostTable) Delete(name string) {
+  ip, ok := t.byName[name]
+  if !ok {
+    return
+  }
+  delete(t.byName, name)
+
+  names := t.byAddr[ip]
+  i, _ := slices.BinarySearch(names, name)
+  if names = slices.Delete(names, i, i+1); len(names) == 0 {
+    delete(t.byAddr, ip)
+  } else {
+    t.byAddr[ip] = names
+  }
+}
+
+// Lookup returns the address of name.
+func (t *HostTable) Lookup(name string) (IPAddr, bool) {
+  ip, ok := t.byName[name]
+  return ip, ok
+}
+
+// Names returns the names that map to ip, sorted.
+func (t *HostTable) Names(ip IPAddr) []string {
+  return slices.Clone(t.byAddr[ip])
+}
+
+// Len returns the number of names in t.
+func (t *HostTable) Len() int {
+  return len(t.byName)
+}
+
+// ByName yields every name and its address, sorted by name.
+func (t *HostTable) ByName() iter.Seq2[string, IPAddr] {
+  return func(yield func(string, IPAddr) bool) {
+    names := make([]string, 0, len(t.byName))
+    for name := range t.byName {
+      names = append(names, name)
+    }
+    slices.Sort(names)
+
+    for _, name := range names {
+      if !yield(name, t.byName[name]) {
+        return
+      }
+    }
+  }
+}
+
+// ByAddr yields every name and its address, sorted numerically by address
+// and then by name.
+func (t *HostTable) ByAddr() iter.Seq2[string, IPAddr] {
+  return func(yield func(string, IPAddr) bool) {
+    addrs := make([]IPAddr, 0, len(t.byAddr))
+    for ip := range t.byAddr {
+      addrs = append(addrs, ip)
+    }
+    slices.SortFunc(addrs, Compare)
+
+    for _, ip := range addrs {
+      for _, name := range t.byAddr[ip] {
+        if !yield(name, ip) {
+          return
+        }
+      }
+    }
+  }
+}
+
+// InRange yields the names whose addresses are in r, sorted as by ByAddr.
+func (t *HostTable) InRange(r IPRange) iter.Seq2[string, IPAddr] {
+  return func(yield func(string, IPAddr) bool) {
+    for name, ip := range t.ByAddr() {
+      if r.Contains(ip) && !yield(name, ip) {
+        return
+      }
+    }
+  }
+}
+
+// ReadHosts reads a table in the format of /etc/hosts: each line holds an
+// address followed by a canonical name and any aliases, and '#' starts a
+// comment. As in the resolver, the first line naming a host wins, and
+// malformed lines, such as an address with no names or one ParseIPAddr
+// rejects, like "010.0.0.1", are skipped. ReadHosts still returns the
+// table in that case, with an error listing the lines it skipped. Lines
+// with IPv6 addresses are skipped silently, since HostTable holds IPv4
+// only. Unlike the resolver, names are matched case-sensitively.
+func ReadHosts(r io.Reader) (*HostTable, error) {
+  t := new(HostTable)
+  var errs []error
+  s := bufio.NewScanner(r)
+  for n := 1; s.Scan(); n++ {
+    line, _, _ := strings.Cut(s.Text(), "#")
+    fields := strings.Fields(line)
+    if len(fields) == 0 || strings.Contains(fields[0], ":") {
+      continue
+    }
+    if len(fields) == 1 {
+      errs = append(errs, fmt.Errorf("ipaddr: hosts line %d: address %s has no names", n, fields[0]))
+      continue
+    }
+
+    ip, err := ParseIPAddr(fields[0])
+    if err != nil {
+      errs = append(errs, fmt.Errorf("ipaddr: hosts line %d: %w", n, err))
+      continue
+    }
+    for _, name := range fields[1:] {
+      if _, ok := t.Lookup(name); !ok {
+        t.Set(name, ip)
+      }
+    }
+  }
+  if err := s.Err(); err != nil {
+    errs = append(errs, err)
+  }
+  return t, errors.Join(errs...)
+}
+
+// ReadHostsFile reads the hosts file at path, usually "/etc/hosts", as
+// ReadHosts does.
+func ReadHostsFile(path string) (*HostTable, error) {
+  f, err := os.Open(path)
+  if err != nil {
+    return nil, err
+  }
+  defer f.Close()
+  return ReadHosts(f)
+}
@@ -0,0 +1,183 @@
+package ipaddr
+
+import (
+  "errors"
+  "fmt"
+  "iter"
+  "slices"
+  "strings"
+  "testing"
+)
+
+func TestNextPrev(t *testing.T) {
+  tests := []struct{ ip, next IPAddr }{
+    {IPAddr{10, 0, 0, 1}, IPAddr{10, 0, 0, 2}},
+    {IPAddr{10, 0, 0, 255}, IPAddr{10, 0, 1, 0}},
+    {IPAddr{10, 255, 255, 255}, IPAddr{11, 0, 0, 0}},
+    {IPAddr{255, 255, 255, 255}, IPAddr{0, 0, 0, 0}},
+  }
+  for _, tt := range tests {
+    if got := tt.ip.Next(); got != tt.next {
+      t.Errorf("%v.Next() = %v, want %v", tt.ip, got, tt.next)
+    }
+    if got := tt.next.Prev(); got != tt.ip {
+      t.Errorf("%v.Prev() = %v, want %v", tt.next, got, tt.ip)
+    }
+  }
+}
+
+func TestIPRange(t *testing.T) {
+  r, err := ParseIPRange("10.0.0.250-10.0.1.2")
+  if err != nil {
+    t.Fatal(err)
+  }
+  if r.String() != "10.0.0.250-10.0.1.2" || r.Len() != 9 {
+    t.Errorf("range %v has %d addresses, want 9", r, r.Len())
+  }
+  var got []string
+  for ip := range r.All() {
+    got = append(got, ip.String())
+  }
+  if len(got) != 9 || got[5] != "10.0.0.255" || got[6] != "10.0.1.0" || got[8] != "10.0.1.2" {
+    t.Errorf("%v.All() = %v", r, got)
+  }
+  if !r.Contains(IPAddr{10, 0, 1, 0}) || r.Contains(IPAddr{10, 0, 1, 3}) || r.Contains(IPAddr{10, 0, 0, 249}) {
+    t.Errorf("%v.Contains is wrong at the edges", r)
+  }
+
+  all := IPRange{Last: IPAddr{255, 255, 255, 255}}
+  if all.Len() != 1<<32 {
+    t.Errorf("%v.Len() = %d, want 2^32", all, all.Len())
+  }
+  one, err := ParseIPRange("8.8.8.8")
+  if err != nil || one.Len() != 1 {
+    t.Errorf("ParseIPRange(8.8.8.8) = %v, %v, want a range of one", one, err)
+  }
+
+  bad := []struct {
+    in  string
+    pos int
+  }{
+    {"10.0.0.5-10.0.0.1", 9},
+    {"10.0.0.1-10.0.0", 15},
+    {"10.0.0.x-10.0.0.5", 7},
+  }
+  for _, tt := range bad {
+    _, err := ParseIPRange(tt.in)
+    var pe *ParseError
+    if !errors.As(err, &pe) || pe.Pos != tt.pos {
+      t.Errorf("ParseIPRange(%q) error = %v, want a *ParseError at position %d", tt.in, err, tt.pos)
+    }
+  }
+}
+
+func collect(seq iter.Seq2[string, IPAddr]) []string {
+  var out []string
+  for name, ip := range seq {
+    out = append(out, fmt.Sprintf("%v: %v", name, ip))
+  }
+  return out
+}
+
+func TestHostTable(t *testing.T) {
+  var tab HostTable
+  tab.Set("loopback", IPAddr{127, 0, 0, 1})
+  tab.Set("googleDNS", IPAddr{8, 8, 8, 8})
+  tab.Set("router", IPAddr{10, 0, 0, 1})
+  tab.Set("gateway", IPAddr{10, 0, 0, 1})
+  tab.Set("nas", IPAddr{10, 0, 0, 9})
+  tab.Set("nas", IPAddr{10, 0, 0, 20})
+
+  if got, want := collect(tab.ByName()), []string{
+    "gateway: 10.0.0.1",
+    "googleDNS: 8.8.8.8",
+    "loopback: 127.0.0.1",
+    "nas: 10.0.0.20",
+    "router: 10.0.0.1",
+  }; !slices.Equal(got, want) {
+    t.Errorf("ByName = %q, want %q", got, want)
+  }
+  // Numeric order puts 8.8.8.8 before 10.x, and 10.0.0.20 after 10.0.0.1.
+  if got, want := collect(tab.ByAddr()), []string{
+    "googleDNS: 8.8.8.8",
+    "gateway: 10.0.0.1",
+    "router: 10.0.0.1",
+    "nas: 10.0.0.20",
+    "loopback: 127.0.0.1",
+  }; !slices.Equal(got, want) {
+    t.Errorf("ByAddr = %q, want %q", got, want)
+  }
+  r, _ := ParseIPRange("10.0.0.1-10.0.0.50")
+  if got, want := collect(tab.InRange(r)), []string{
+    "gateway: 10.0.0.1",
+    "router: 10.0.0.1",
+    "nas: 10.0.0.20",
+  }; !slices.Equal(got, want) {
+    t.Errorf("InRange(%v) = %q, want %q", r, got, want)
+  }
+
+  if ip, ok := tab.Lookup("nas"); !ok || ip != (IPAddr{10, 0, 0, 20}) {
+    t.Errorf("Lookup(nas) = %v, %t", ip, ok)
+  }
+  if got := tab.Names(IPAddr{10, 0, 0, 1}); !slices.Equal(got, []string{"gateway", "router"}) {
+    t.Errorf("Names(10.0.0.1) = %q", got)
+  }
+  if got := tab.Names(IPAddr{10, 0, 0, 9}); len(got) != 0 {
+    t.Errorf("Names(10.0.0.9) = %q after nas moved away", got)
+  }
+
+  tab.Delete("router")
+  tab.Delete("missing")
+  if _, ok := tab.Lookup("router"); ok || tab.Len() != 4 {
+    t.Errorf("after Delete(router), Len() = %d, Lookup(router) found = %t", tab.Len(), ok)
+  }
+  if got := tab.Names(IPAddr{10, 0, 0, 1}); !slices.Equal(got, []string{"gateway"}) {
+    t.Errorf("Names(10.0.0.1) = %q after deleting router", got)
+  }
+}
+
+func TestReadHosts(t *testing.T) {
+  const hosts = `# The usual entries.
+127.0.0.1	localhost loopback
+::1		localhost ip6-localhost
+
+10.0.0.1   gateway router   # the box in the hall
+10.0.0.2   router
+`
+  tab, err := ReadHosts(strings.NewReader(hosts))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if got, want := collect(tab.ByName()), []string{
+    "gateway: 10.0.0.1",
+    "localhost: 127.0.0.1",
+    "loopback: 127.0.0.1",
+    "router: 10.0.0.1",
+  }; !slices.Equal(got, want) {
+    t.Errorf("ReadHosts = %q, want %q", got, want)
+  }
+
+  // Malformed lines are skipped, and reported with their line numbers.
+  const messy = `127.0.0.1 localhost
+10.0.0.256 big
+010.0.0.1 padded
+10.0.0.1
+10.0.0.2 nas
+`
+  tab, err = ReadHosts(strings.NewReader(messy))
+  if got, want := collect(tab.ByName()), []string{
+    "localhost: 127.0.0.1",
+    "nas: 10.0.0.2",
+  }; !slices.Equal(got, want) {
+    t.Errorf("ReadHosts of a messy file = %q, want %q", got, want)
+  }
+  for _, line := range []string{"line 2", "line 3", "line 4"} {
+    if err == nil || !strings.Contains(err.Error(), line) {
+      t.Errorf("ReadHosts error = %v, want one naming %s", err, line)
+    }
+  }
+  var pe *ParseError
+  if !errors.As(err, &pe) {
+    t.Errorf("ReadHosts error = %v, want it to wrap a *ParseError", err)
+  }
+}
@@ -0,0 +1,11 @@
+package ipaddr
+
+import "fmt"
+
+// IPAddr is an IPv4 address stored as its four octets.
+type IPAddr [4]byte
+
+// String formats ip in dotted-quad notation, e.g. "127.0.0.1".
+func (ip IPAddr) String() string {
+  return fmt.Sprintf("%d.%d.%d.%d", ip[0], ip[1], ip[2], ip[3])
+}
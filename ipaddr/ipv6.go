@@ -0,0 +1,196 @@
+package ipaddr
+
+import (
+  "fmt"
+  "strconv"
+  "strings"
+)
+
+// IPv6Addr is an IPv6 address stored as its sixteen bytes, in network
+// order.
+type IPv6Addr [16]byte
+
+// v4InV6Prefix is the first twelve bytes of an IPv4-mapped address,
+// ::ffff:0:0/96.
+var v4InV6Prefix = [12]byte{10: 0xff, 11: 0xff}
+
+// To16 returns ip as an IPv4-mapped IPv6 address, e.g. 10.0.0.1 becomes
+// ::ffff:10.0.0.1.
+func (ip IPAddr) To16() IPv6Addr {
+  var ip6 IPv6Addr
+  copy(ip6[:], v4InV6Prefix[:])
+  copy(ip6[12:], ip[:])
+  return ip6
+}
+
+// IsIPv4Mapped reports whether ip is in ::ffff:0:0/96, the block that
+// holds IPv4 addresses.
+func (ip IPv6Addr) IsIPv4Mapped() bool {
+  return [12]byte(ip[:12]) == v4InV6Prefix
+}
+
+// To4 returns the IPv4 address held in an IPv4-mapped ip. The boolean is
+// false if ip is not IPv4-mapped.
+func (ip IPv6Addr) To4() (IPAddr, bool) {
+  if !ip.IsIPv4Mapped() {
+    return IPAddr{}, false
+  }
+  return IPAddr(ip[12:]), true
+}
+
+// String formats ip in the canonical form of RFC 5952: lowercase hex
+// without leading zeros, the longest run of two or more zero groups
+// compressed to "::", and IPv4-mapped addresses ending in dotted-quad,
+// e.g. "2001:db8::1" or "::ffff:192.0.2.1".
+func (ip IPv6Addr) String() string {
+  if v4, ok := ip.To4(); ok {
+    return "::ffff:" + v4.String()
+  }
+
+  var groups [8]uint16
+  for i := range groups {
+    groups[i] = uint16(ip[2*i])<<8 | uint16(ip[2*i+1])
+  }
+
+  // Find the first longest run of zero groups; a lone zero group is not
+  // compressed.
+  start, n := -1, 1
+  for i := 0; i < len(groups); {
+    j := i
+    for j < len(groups) && groups[j] == 0 {
+      j++
+    }
+    if j-i > n {
+      start, n = i, j-i
+    }
+    i = j + 1
+  }
+
+  var b strings.Builder
+  for i := 0; i < len(groups); i++ {
+    if i == start {
+      b.WriteString("::")
+      i += n - 1
+      continue
+    }
+    if i > 0 && i != start+n {
+      b.WriteByte(':')
+    }
+    b.WriteString(strconv.FormatUint(uint64(groups[i]), 16))
+  }
+  return b.String()
+}
+
+// ParseIPv6Addr parses s as an IPv6 address: up to eight groups of one to
+// four hex digits separated by colons, with at most one "::" standing for
+// one or more zero groups. The last 32 bits may be written as a
+// dotted-quad, as in "::ffff:192.0.2.1". Hex digits may be in either
+// case, so unlike ParseIPAddr the input need not be canonical.
+func ParseIPv6Addr(s string) (IPv6Addr, error) {
+  fail := func(pos int, format string, args ...any) (IPv6Addr, error) {
+    return IPv6Addr{}, &ParseError{Input: s, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+  }
+
+  var groups []uint16
+  ellipsis := -1 // index in groups where "::" stands, if any
+
+  i := 0
+  if strings.HasPrefix(s, "::") {
+    ellipsis, i = 0, 2
+  }
+  for i < len(s) {
+    if len(groups) == 8 {
+      return fail(i, "too many groups")
+    }
+
+    // A group containing a '.' is the dotted-quad tail.
+    end := strings.IndexByte(s[i:], ':')
+    if end < 0 {
+      end = len(s)
+    } else {
+      end += i
+    }
+    if strings.IndexByte(s[i:end], '.') >= 0 {
+      if end < len(s) {
+        return fail(end, "IPv4 part must come last")
+      }
+      if len(groups) > 6 {
+        return fail(i, "too many groups")
+      }
+      v4, err := ParseIPAddr(s[i:])
+      if err != nil {
+        pe := err.(*ParseError)
+        return fail(i+pe.Pos, "%s", pe.Msg)
+      }
+      groups = append(groups, uint16(v4[0])<<8|uint16(v4[1]), uint16(v4[2])<<8|uint16(v4[3]))
+      i = len(s)
+      break
+    }
+
+    start, v := i, 0
+    for i < len(s) && i-start < 5 {
+      d, ok := unhex(s[i])
+      if !ok {
+        break
+      }
+      v = v<<4 | d
+      i++
+    }
+    switch {
+    case i-start > 4:
+      return fail(start, "group has more than 4 hex digits")
+    case i == start && i == len(s):
+      return fail(i, "missing group")
+    case i == start:
+      return fail(i, "unexpected %q, want a hex digit", s[i])
+    }
+    groups = append(groups, uint16(v))
+
+    if i == len(s) {
+      break
+    }
+    if s[i] != ':' {
+      return fail(i, "unexpected %q, want ':'", s[i])
+    }
+    i++
+    if i < len(s) && s[i] == ':' {
+      if ellipsis >= 0 {
+        return fail(i-1, "more than one \"::\"")
+      }
+      ellipsis = len(groups)
+      i++
+    } else if i == len(s) {
+      return fail(i, "missing group")
+    }
+  }
+
+  if ellipsis < 0 {
+    if len(groups) < 8 {
+      return fail(len(s), "want 8 groups, have %d", len(groups))
+    }
+  } else {
+    if len(groups) == 8 {
+      return fail(strings.Index(s, "::"), "\"::\" must stand for at least one group")
+    }
+    zeros := make([]uint16, 8-len(groups))
+    groups = append(groups[:ellipsis], append(zeros, groups[ellipsis:]...)...)
+  }
+
+  var ip IPv6Addr
+  for n, g := range groups {
+    ip[2*n], ip[2*n+1] = byte(g>>8), byte(g)
+  }
+  return ip, nil
+}
+
+func unhex(c byte) (int, bool) {
+  switch {
+  case '0' <= c && c <= '9':
+    return int(c - '0'), true
+  case 'a' <= c && c <= 'f':
+    return int(c-'a') + 10, true
+  case 'A' <= c && c <= 'F':
+    return int(c-'A') + 10, true
+  }
+  return 0, false
+}
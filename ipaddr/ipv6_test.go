@@ -0,0 +1,140 @@
+package ipaddr
+
+import (
+  "errors"
+  "net/netip"
+  "testing"
+)
+
+func TestIPv6AddrString(t *testing.T) {
+  tests := []struct {
+    ip   IPv6Addr
+    want string
+  }{
+    {IPv6Addr{}, "::"},
+    {IPv6Addr{15: 1}, "::1"},
+    {IPv6Addr{0x20, 0x01, 0x0d, 0xb8, 15: 1}, "2001:db8::1"},
+    // A single zero group is not compressed.
+    {IPv6Addr{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1}, "2001:db8:0:1:1:1:1:1"},
+    // The longest run wins, and the first of equal runs.
+    {IPv6Addr{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0}, "2001:db8:0:0:1::"},
+    {IPv6Addr{0x20, 0x01, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 1}, "2001::1:0:0:1:1"},
+    {IPv6Addr{0xfe, 0x80, 8: 0x02, 0x1a, 0x2b, 0xff, 0xfe, 0x3c, 0x4d, 0x5e}, "fe80::21a:2bff:fe3c:4d5e"},
+    {IPv6Addr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+    {IPAddr{192, 0, 2, 1}.To16(), "::ffff:192.0.2.1"},
+  }
+  for _, tt := range tests {
+    if got := tt.ip.String(); got != tt.want {
+      t.Errorf("%#v.String() = %q, want %q", [16]byte(tt.ip), got, tt.want)
+    }
+  }
+}
+
+func TestParseIPv6Addr(t *testing.T) {
+  tests := []struct {
+    in, want string
+  }{
+    {"::", "::"},
+    {"::1", "::1"},
+    {"1::", "1::"},
+    {"2001:DB8::0001", "2001:db8::1"},
+    {"2001:db8:0:0:0:0:0:1", "2001:db8::1"},
+    {"2001:db8::0:1", "2001:db8::1"},
+    {"1:2:3:4:5:6:7:8", "1:2:3:4:5:6:7:8"},
+    {"1:2:3:4:5:6:7::", "1:2:3:4:5:6:7:0"},
+    {"::ffff:10.0.0.1", "::ffff:10.0.0.1"},
+    {"0:0:0:0:0:ffff:10.0.0.1", "::ffff:10.0.0.1"},
+    {"64:ff9b::192.0.2.33", "64:ff9b::c000:221"},
+  }
+  for _, tt := range tests {
+    ip, err := ParseIPv6Addr(tt.in)
+    if err != nil || ip.String() != tt.want {
+      t.Errorf("ParseIPv6Addr(%q) = %v, %v, want %s", tt.in, ip, err, tt.want)
+    }
+  }
+}
+
+func TestParseIPv6AddrErrors(t *testing.T) {
+  tests := []struct {
+    in  string
+    pos int
+    msg string
+  }{
+    {"", 0, "want 8 groups, have 0"},
+    {":1", 0, "unexpected ':', want a hex digit"},
+    {"1:", 2, "missing group"},
+    {"1:2:3", 5, "want 8 groups, have 3"},
+    {"1::2::3", 4, "more than one \"::\""},
+    {"1:::2", 3, "unexpected ':', want a hex digit"},
+    {"12345::", 0, "group has more than 4 hex digits"},
+    {"1:2:3:4:5:6:7:8:9", 16, "too many groups"},
+    {"1:2:3:4::5:6:7:8", 7, "\"::\" must stand for at least one group"},
+    {"g::", 0, "unexpected 'g', want a hex digit"},
+    {"1 ::", 1, "unexpected ' ', want ':'"},
+    {"::1.2.3.4:5", 9, "IPv4 part must come last"},
+    {"::ffff:1.2.3.256", 13, "octet out of range 0-255"},
+    {"1:2:3:4:5:6:7:1.2.3.4", 14, "too many groups"},
+  }
+  for _, tt := range tests {
+    _, err := ParseIPv6Addr(tt.in)
+    var pe *ParseError
+    if !errors.As(err, &pe) {
+      t.Errorf("ParseIPv6Addr(%q) error = %v, want a *ParseError", tt.in, err)
+      continue
+    }
+    if pe.Pos != tt.pos || pe.Msg != tt.msg {
+      t.Errorf("ParseIPv6Addr(%q) error = %+v, want position %d, %q", tt.in, *pe, tt.pos, tt.msg)
+    }
+  }
+}
+
+func TestIPv4Mapped(t *testing.T) {
+  ip := IPAddr{10, 1, 2, 3}
+  ip6 := ip.To16()
+  if !ip6.IsIPv4Mapped() {
+    t.Errorf("%v.IsIPv4Mapped() = false", ip6)
+  }
+  if got, ok := ip6.To4(); !ok || got != ip {
+    t.Errorf("%v.To4() = %v, %t, want %v, true", ip6, got, ok, ip)
+  }
+
+  if got, ok := (IPv6Addr{15: 1}).To4(); ok {
+    t.Errorf("::1.To4() = %v, true, want false", got)
+  }
+}
+
+func FuzzParseIPv6AddrRoundTrip(f *testing.F) {
+  f.Add([]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1})
+  f.Add(make([]byte, 16))
+  f.Add([]byte{10: 0xff, 11: 0xff, 12: 127, 15: 1})
+  f.Fuzz(func(t *testing.T, b []byte) {
+    if len(b) != 16 {
+      return
+    }
+    ip := IPv6Addr(b)
+    if want := netip.AddrFrom16(ip).String(); ip.String() != want {
+      t.Errorf("%v.String() = %q, want %q as net/netip formats it", [16]byte(ip), ip.String(), want)
+    }
+    got, err := ParseIPv6Addr(ip.String())
+    if err != nil || got != ip {
+      t.Errorf("ParseIPv6Addr(%q) = %v, %v, want %v", ip.String(), got, err, ip)
+    }
+  })
+}
+
+func FuzzParseIPv6Addr(f *testing.F) {
+  for _, s := range []string{"::", "2001:db8::1", "::ffff:1.2.3.4", "1:2:3:4:5:6:7:8", "1::2::3", "FE80::1"} {
+    f.Add(s)
+  }
+  f.Fuzz(func(t *testing.T, s string) {
+    ip, err := ParseIPv6Addr(s)
+    if err != nil {
+      return
+    }
+    // The canonical form must parse back to the same address.
+    got, err := ParseIPv6Addr(ip.String())
+    if err != nil || got != ip {
+      t.Errorf("ParseIPv6Addr(%q) = %v, but its String parses as %v, %v", s, ip, got, err)
+    }
+  })
+}
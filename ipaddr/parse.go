@@ -0,0 +1,63 @@
+package ipaddr
+
+import "fmt"
+
+// ParseError reports why an address could not be parsed. Pos is the byte
+// offset in Input where the problem was found.
+type ParseError struct {
+  Input string
+  Pos   int
+  Msg   string
+}
+
+func (e *ParseError) Error() string {
+  return fmt.Sprintf("ipaddr: parsing %q: %s at position %d", e.Input, e.Msg, e.Pos)
+}
+
+// ParseIPAddr parses s as an IPv4 address in dotted-quad notation, such
+// as "192.168.0.1". It is strict: there must be exactly four decimal
+// octets, each in 0-255 and without leading zeros, so that only the
+// output of String is accepted.
+func ParseIPAddr(s string) (IPAddr, error) {
+  var ip IPAddr
+  fail := func(pos int, format string, args ...any) (IPAddr, error) {
+    return IPAddr{}, &ParseError{Input: s, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+  }
+
+  i := 0
+  for n := range ip {
+    if n > 0 {
+      if i == len(s) {
+        return fail(i, "want 4 octets, have %d", n)
+      }
+      if s[i] != '.' {
+        return fail(i, "unexpected %q, want '.'", s[i])
+      }
+      i++
+    }
+
+    start, v := i, 0
+    for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+      if i > start && s[start] == '0' {
+        return fail(start, "octet has a leading zero")
+      }
+      v = v*10 + int(s[i]-'0')
+      if v > 255 {
+        return fail(start, "octet out of range 0-255")
+      }
+      i++
+    }
+    if i == start {
+      if i == len(s) {
+        return fail(i, "missing octet")
+      }
+      return fail(i, "unexpected %q, want a digit", s[i])
+    }
+    ip[n] = byte(v)
+  }
+
+  if i < len(s) {
+    return fail(i, "unexpected %q after the last octet", s[i])
+  }
+  return ip, nil
+}
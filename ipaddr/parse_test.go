@@ -0,0 +1,87 @@
+package ipaddr
+
+import (
+  "errors"
+  "testing"
+)
+
+func TestParseIPAddr(t *testing.T) {
+  tests := []struct {
+    in   string
+    want IPAddr
+  }{
+    {"0.0.0.0", IPAddr{0, 0, 0, 0}},
+    {"127.0.0.1", IPAddr{127, 0, 0, 1}},
+    {"8.8.4.4", IPAddr{8, 8, 4, 4}},
+    {"255.255.255.255", IPAddr{255, 255, 255, 255}},
+    {"10.200.30.4", IPAddr{10, 200, 30, 4}},
+  }
+  for _, tt := range tests {
+    got, err := ParseIPAddr(tt.in)
+    if err != nil || got != tt.want {
+      t.Errorf("ParseIPAddr(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+    }
+  }
+}
+
+func TestParseIPAddrErrors(t *testing.T) {
+  tests := []struct {
+    in  string
+    pos int
+    msg string
+  }{
+    {"", 0, "missing octet"},
+    {"1.2.3", 5, "want 4 octets, have 3"},
+    {"1.2.3.", 6, "missing octet"},
+    {"1.2..4", 4, "unexpected '.', want a digit"},
+    {"1.2.3.4.", 7, "unexpected '.' after the last octet"},
+    {"1.2.3.4 ", 7, "unexpected ' ' after the last octet"},
+    {"1.2.3.256", 6, "octet out of range 0-255"},
+    {"1.2.3.1000", 6, "octet out of range 0-255"},
+    {"1.02.3.4", 2, "octet has a leading zero"},
+    {"00.1.2.3", 0, "octet has a leading zero"},
+    {"1,2.3.4", 1, "unexpected ',', want '.'"},
+    {"-1.2.3.4", 0, "unexpected '-', want a digit"},
+    {"::1", 0, "unexpected ':', want a digit"},
+  }
+  for _, tt := range tests {
+    _, err := ParseIPAddr(tt.in)
+    var pe *ParseError
+    if !errors.As(err, &pe) {
+      t.Errorf("ParseIPAddr(%q) error = %v, want a *ParseError", tt.in, err)
+      continue
+    }
+    if pe.Input != tt.in || pe.Pos != tt.pos || pe.Msg != tt.msg {
+      t.Errorf("ParseIPAddr(%q) error = %+v, want position %d, %q", tt.in, *pe, tt.pos, tt.msg)
+    }
+  }
+}
+
+func FuzzParseIPAddrRoundTrip(f *testing.F) {
+  f.Add(byte(127), byte(0), byte(0), byte(1))
+  f.Add(byte(255), byte(255), byte(255), byte(255))
+  f.Add(byte(0), byte(10), byte(100), byte(9))
+  f.Fuzz(func(t *testing.T, a, b, c, d byte) {
+    ip := IPAddr{a, b, c, d}
+    got, err := ParseIPAddr(ip.String())
+    if err != nil || got != ip {
+      t.Errorf("ParseIPAddr(%q) = %v, %v, want %v", ip.String(), got, err, ip)
+    }
+  })
+}
+
+func FuzzParseIPAddr(f *testing.F) {
+  for _, s := range []string{"1.2.3.4", "01.2.3.4", "1.2.3.256", "1..2.3", "::1", ""} {
+    f.Add(s)
+  }
+  f.Fuzz(func(t *testing.T, s string) {
+    ip, err := ParseIPAddr(s)
+    if err != nil {
+      return
+    }
+    // Parsing is strict, so anything accepted is already canonical.
+    if ip.String() != s {
+      t.Errorf("ParseIPAddr(%q) = %v, which formats differently", s, ip)
+    }
+  })
+}
@@ -0,0 +1,206 @@
+package ipaddr
+
+import (
+  "cmp"
+  "fmt"
+  "iter"
+  "slices"
+  "strconv"
+  "strings"
+)
+
+// IPPrefix is an IPv4 network in CIDR notation: an address and the
+// number of leading bits, 0-32, that identify the network. Methods of a
+// prefix whose Bits are out of range treat it as containing nothing.
+type IPPrefix struct {
+  Addr IPAddr
+  Bits int
+}
+
+// IsValid reports whether p.Bits is in the range 0-32.
+func (p IPPrefix) IsValid() bool {
+  return 0 <= p.Bits && p.Bits <= 32
+}
+
+// ParseIPPrefix parses s as a CIDR prefix such as "192.168.0.0/24". The
+// address need not be the network address; use Masked to clear the host
+// bits.
+func ParseIPPrefix(s string) (IPPrefix, error) {
+  slash := strings.IndexByte(s, '/')
+  if slash < 0 {
+    return IPPrefix{}, &ParseError{Input: s, Pos: len(s), Msg: "missing '/'"}
+  }
+  ip, err := ParseIPAddr(s[:slash])
+  if err != nil {
+    pe := err.(*ParseError)
+    return IPPrefix{}, &ParseError{Input: s, Pos: pe.Pos, Msg: pe.Msg}
+  }
+
+  bits := s[slash+1:]
+  n, err := strconv.Atoi(bits)
+  if err != nil || n < 0 || n > 32 || bits != strconv.Itoa(n) {
+    return IPPrefix{}, &ParseError{Input: s, Pos: slash + 1, Msg: "prefix length must be 0-32"}
+  }
+  return IPPrefix{Addr: ip, Bits: n}, nil
+}
+
+// String formats p in CIDR notation, e.g. "10.0.0.0/8".
+func (p IPPrefix) String() string {
+  return fmt.Sprintf("%v/%d", p.Addr, p.Bits)
+}
+
+// Mask returns p's netmask, e.g. 255.255.255.0 for a /24, or 0.0.0.0 if p
+// is invalid.
+func (p IPPrefix) Mask() IPAddr {
+  if !p.IsValid() {
+    return IPAddr{}
+  }
+  return fromUint32(p.mask())
+}
+
+// mask returns p's netmask as a number. p must be valid.
+func (p IPPrefix) mask() uint32 {
+  return ^uint32(0) << (32 - p.Bits)
+}
+
+// Masked returns p with the host bits of its address cleared, or p
+// unchanged if it is invalid.
+func (p IPPrefix) Masked() IPPrefix {
+  if !p.IsValid() {
+    return p
+  }
+  return IPPrefix{Addr: p.Network(), Bits: p.Bits}
+}
+
+// Network returns the first address in p, or 0.0.0.0 if p is invalid.
+func (p IPPrefix) Network() IPAddr {
+  if !p.IsValid() {
+    return IPAddr{}
+  }
+  return fromUint32(p.Addr.uint32() & p.mask())
+}
+
+// Broadcast returns the last address in p, or 0.0.0.0 if p is invalid.
+func (p IPPrefix) Broadcast() IPAddr {
+  if !p.IsValid() {
+    return IPAddr{}
+  }
+  return fromUint32(p.Addr.uint32() | ^p.mask())
+}
+
+// Contains reports whether ip is in p. An invalid p contains nothing.
+func (p IPPrefix) Contains(ip IPAddr) bool {
+  return p.IsValid() && (ip.uint32()^p.Addr.uint32())&p.mask() == 0
+}
+
+// NumAddrs returns how many addresses p spans, including its network and
+// broadcast addresses, or 0 if p is invalid.
+func (p IPPrefix) NumAddrs() uint64 {
+  if !p.IsValid() {
+    return 0
+  }
+  return 1 << (32 - p.Bits)
+}
+
+// NumHosts returns how many addresses in p can be assigned to hosts: all
+// but the network and broadcast addresses, except that both addresses of
+// a /31 point-to-point link (RFC 3021) and the single address of a /32
+// are usable. It returns 0 if p is invalid.
+func (p IPPrefix) NumHosts() uint64 {
+  if !p.IsValid() {
+    return 0
+  }
+  if p.Bits >= 31 {
+    return p.NumAddrs()
+  }
+  return p.NumAddrs() - 2
+}
+
+// Hosts yields the addresses counted by NumHosts in ascending order.
+func (p IPPrefix) Hosts() iter.Seq[IPAddr] {
+  return func(yield func(IPAddr) bool) {
+    first := p.Network().uint32()
+    if p.Bits < 31 {
+      first++
+    }
+    for n := range p.NumHosts() {
+      if !yield(fromUint32(first + uint32(n))) {
+        return
+      }
+    }
+  }
+}
+
+// Subnets yields the prefixes of length bits that p divides into, in
+// ascending order. It returns an error if p is invalid, or if bits is
+// shorter than p or longer than 32.
+func (p IPPrefix) Subnets(bits int) (iter.Seq[IPPrefix], error) {
+  if !p.IsValid() {
+    return nil, fmt.Errorf("ipaddr: invalid prefix %v", p)
+  }
+  if bits < p.Bits || bits > 32 {
+    return nil, fmt.Errorf("ipaddr: cannot split %v into /%d subnets", p, bits)
+  }
+  return func(yield func(IPPrefix) bool) {
+    first := p.Network().uint32()
+    step := uint64(1) << (32 - bits)
+    for n := range uint64(1) << (bits - p.Bits) {
+      if !yield(IPPrefix{Addr: fromUint32(first + uint32(n*step)), Bits: bits}) {
+        return
+      }
+    }
+  }, nil
+}
+
+// Supernet returns the prefix one bit shorter that contains p. The
+// supernet of a /0 is itself, and an invalid p is returned unchanged.
+func (p IPPrefix) Supernet() IPPrefix {
+  if p.Bits == 0 || !p.IsValid() {
+    return p.Masked()
+  }
+  return IPPrefix{Addr: p.Addr, Bits: p.Bits - 1}.Masked()
+}
+
+// Aggregate returns the shortest list of prefixes covering exactly the
+// addresses covered by prefixes, sorted by address. Prefixes inside
+// others are dropped, and adjacent halves of a larger prefix are merged
+// into it, so 10.0.0.0/25 and 10.0.0.128/25 become 10.0.0.0/24. Invalid
+// prefixes cover nothing and are left out.
+func Aggregate(prefixes []IPPrefix) []IPPrefix {
+  sorted := make([]IPPrefix, 0, len(prefixes))
+  for _, p := range prefixes {
+    if p.IsValid() {
+      sorted = append(sorted, p.Masked())
+    }
+  }
+  slices.SortFunc(sorted, func(a, b IPPrefix) int {
+    return cmp.Or(cmp.Compare(a.Addr.uint32(), b.Addr.uint32()), cmp.Compare(a.Bits, b.Bits))
+  })
+
+  var out []IPPrefix
+  for _, p := range sorted {
+    if len(out) > 0 && out[len(out)-1].Contains(p.Addr) {
+      continue
+    }
+    out = append(out, p)
+
+    // Merging two halves may complete the other half of a larger prefix
+    // already on the list, so keep going until nothing merges.
+    for len(out) >= 2 {
+      a, b := out[len(out)-2], out[len(out)-1]
+      if a.Bits != b.Bits || a.Bits == 0 || a.Supernet() != b.Supernet() {
+        break
+      }
+      out = append(out[:len(out)-2], a.Supernet())
+    }
+  }
+  return out
+}
+
+func (ip IPAddr) uint32() uint32 {
+  return uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+}
+
+func fromUint32(n uint32) IPAddr {
+  return IPAddr{byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}
+}
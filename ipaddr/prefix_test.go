@@ -0,0 +1,223 @@
+package ipaddr
+
+import (
+  "errors"
+  "slices"
+  "testing"
+)
+
+func mustPrefix(t *testing.T, s string) IPPrefix {
+  t.Helper()
+  p, err := ParseIPPrefix(s)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return p
+}
+
+func TestParseIPPrefix(t *testing.T) {
+  p, err := ParseIPPrefix("192.168.1.77/24")
+  if want := (IPPrefix{Addr: IPAddr{192, 168, 1, 77}, Bits: 24}); err != nil || p != want {
+    t.Errorf("ParseIPPrefix = %v, %v, want %v", p, err, want)
+  }
+
+  bad := []struct {
+    in  string
+    pos int
+  }{
+    {"10.0.0.0", 8},
+    {"10.0.0/8", 6},
+    {"10.0.0.0/33", 9},
+    {"10.0.0.0/-1", 9},
+    {"10.0.0.0/08", 9},
+    {"10.0.0.0/", 9},
+  }
+  for _, tt := range bad {
+    _, err := ParseIPPrefix(tt.in)
+    var pe *ParseError
+    if !errors.As(err, &pe) || pe.Input != tt.in || pe.Pos != tt.pos {
+      t.Errorf("ParseIPPrefix(%q) error = %v, want a *ParseError at position %d", tt.in, err, tt.pos)
+    }
+  }
+}
+
+func TestIPPrefix(t *testing.T) {
+  tests := []struct {
+    prefix                   string
+    mask, network, broadcast string
+    addrs, hosts             uint64
+  }{
+    {"192.168.1.77/24", "255.255.255.0", "192.168.1.0", "192.168.1.255", 256, 254},
+    {"10.1.2.3/8", "255.0.0.0", "10.0.0.0", "10.255.255.255", 1 << 24, 1<<24 - 2},
+    {"172.16.5.9/30", "255.255.255.252", "172.16.5.8", "172.16.5.11", 4, 2},
+    {"172.16.5.9/31", "255.255.255.254", "172.16.5.8", "172.16.5.9", 2, 2},
+    {"172.16.5.9/32", "255.255.255.255", "172.16.5.9", "172.16.5.9", 1, 1},
+    {"1.2.3.4/0", "0.0.0.0", "0.0.0.0", "255.255.255.255", 1 << 32, 1<<32 - 2},
+  }
+  for _, tt := range tests {
+    p := mustPrefix(t, tt.prefix)
+    if got := p.Mask().String(); got != tt.mask {
+      t.Errorf("%v.Mask() = %v, want %v", p, got, tt.mask)
+    }
+    if got := p.Network().String(); got != tt.network {
+      t.Errorf("%v.Network() = %v, want %v", p, got, tt.network)
+    }
+    if got := p.Broadcast().String(); got != tt.broadcast {
+      t.Errorf("%v.Broadcast() = %v, want %v", p, got, tt.broadcast)
+    }
+    if got := p.NumAddrs(); got != tt.addrs {
+      t.Errorf("%v.NumAddrs() = %d, want %d", p, got, tt.addrs)
+    }
+    if got := p.NumHosts(); got != tt.hosts {
+      t.Errorf("%v.NumHosts() = %d, want %d", p, got, tt.hosts)
+    }
+  }
+}
+
+func TestContains(t *testing.T) {
+  p := mustPrefix(t, "10.20.0.0/14")
+  for _, tt := range []struct {
+    ip   IPAddr
+    want bool
+  }{
+    {IPAddr{10, 20, 0, 0}, true},
+    {IPAddr{10, 23, 255, 255}, true},
+    {IPAddr{10, 24, 0, 0}, false},
+    {IPAddr{10, 19, 255, 255}, false},
+    {IPAddr{11, 20, 0, 0}, false},
+  } {
+    if got := p.Contains(tt.ip); got != tt.want {
+      t.Errorf("%v.Contains(%v) = %t, want %t", p, tt.ip, got, tt.want)
+    }
+  }
+}
+
+func TestHosts(t *testing.T) {
+  tests := []struct {
+    prefix string
+    want   []string
+  }{
+    {"192.168.0.5/30", []string{"192.168.0.5", "192.168.0.6"}},
+    {"192.168.0.5/31", []string{"192.168.0.4", "192.168.0.5"}},
+    {"192.168.0.5/32", []string{"192.168.0.5"}},
+  }
+  for _, tt := range tests {
+    var got []string
+    for ip := range mustPrefix(t, tt.prefix).Hosts() {
+      got = append(got, ip.String())
+    }
+    if !slices.Equal(got, tt.want) {
+      t.Errorf("%s hosts = %v, want %v", tt.prefix, got, tt.want)
+    }
+  }
+
+  // Stopping early must not run through all 16 million hosts.
+  n := 0
+  for range mustPrefix(t, "10.0.0.0/8").Hosts() {
+    if n++; n == 3 {
+      break
+    }
+  }
+}
+
+func TestSubnets(t *testing.T) {
+  p := mustPrefix(t, "10.0.0.77/24")
+  seq, err := p.Subnets(26)
+  if err != nil {
+    t.Fatal(err)
+  }
+  var got []string
+  for s := range seq {
+    got = append(got, s.String())
+  }
+  want := []string{"10.0.0.0/26", "10.0.0.64/26", "10.0.0.128/26", "10.0.0.192/26"}
+  if !slices.Equal(got, want) {
+    t.Errorf("%v.Subnets(26) = %v, want %v", p, got, want)
+  }
+
+  for _, bits := range []int{23, 33} {
+    if _, err := p.Subnets(bits); err == nil {
+      t.Errorf("%v.Subnets(%d) succeeded", p, bits)
+    }
+  }
+}
+
+func TestSupernet(t *testing.T) {
+  tests := []struct{ in, want string }{
+    {"10.0.1.0/24", "10.0.0.0/23"},
+    {"10.0.0.128/25", "10.0.0.0/24"},
+    {"0.0.0.0/0", "0.0.0.0/0"},
+  }
+  for _, tt := range tests {
+    if got := mustPrefix(t, tt.in).Supernet().String(); got != tt.want {
+      t.Errorf("%s.Supernet() = %s, want %s", tt.in, got, tt.want)
+    }
+  }
+}
+
+func TestAggregate(t *testing.T) {
+  tests := []struct {
+    in, want []string
+  }{
+    {nil, nil},
+    {[]string{"10.0.0.0/25", "10.0.0.128/25"}, []string{"10.0.0.0/24"}},
+    // Not aligned on a /24, so these stay apart.
+    {[]string{"10.0.0.128/25", "10.0.1.0/25"}, []string{"10.0.0.128/25", "10.0.1.0/25"}},
+    // Merges cascade, duplicates and contained prefixes vanish, and host
+    // bits are ignored.
+    {
+      []string{"10.0.3.0/24", "10.0.0.0/24", "10.0.2.9/24", "10.0.1.0/24", "10.0.1.64/26", "10.0.0.0/24", "192.168.0.0/16"},
+      []string{"10.0.0.0/22", "192.168.0.0/16"},
+    },
+    {[]string{"0.0.0.0/1", "128.0.0.0/1"}, []string{"0.0.0.0/0"}},
+  }
+  for _, tt := range tests {
+    var in []IPPrefix
+    for _, s := range tt.in {
+      in = append(in, mustPrefix(t, s))
+    }
+    var got []string
+    for _, p := range Aggregate(in) {
+      got = append(got, p.String())
+    }
+    if !slices.Equal(got, tt.want) {
+      t.Errorf("Aggregate(%v) = %v, want %v", tt.in, got, tt.want)
+    }
+  }
+}
+
+func TestInvalidPrefix(t *testing.T) {
+  for _, bits := range []int{-1, 33, 64} {
+    p := IPPrefix{Addr: IPAddr{10, 0, 0, 1}, Bits: bits}
+    if p.IsValid() {
+      t.Errorf("%v.IsValid() = true", p)
+    }
+    if p.Contains(IPAddr{10, 0, 0, 1}) {
+      t.Errorf("%v.Contains(10.0.0.1) = true", p)
+    }
+    if p.Mask() != (IPAddr{}) || p.Network() != (IPAddr{}) || p.Broadcast() != (IPAddr{}) {
+      t.Errorf("%v: Mask, Network, Broadcast = %v, %v, %v, want 0.0.0.0", p, p.Mask(), p.Network(), p.Broadcast())
+    }
+    if p.NumAddrs() != 0 || p.NumHosts() != 0 {
+      t.Errorf("%v: NumAddrs, NumHosts = %d, %d, want 0", p, p.NumAddrs(), p.NumHosts())
+    }
+    for ip := range p.Hosts() {
+      t.Errorf("%v.Hosts() yielded %v", p, ip)
+    }
+    if p.Masked() != p || p.Supernet() != p {
+      t.Errorf("%v: Masked, Supernet = %v, %v, want it unchanged", p, p.Masked(), p.Supernet())
+    }
+    if _, err := p.Subnets(32); err == nil {
+      t.Errorf("%v.Subnets(32) succeeded", p)
+    }
+    if got := Aggregate([]IPPrefix{p, mustPrefix(t, "10.0.0.0/8")}); len(got) != 1 || got[0].Bits != 8 {
+      t.Errorf("Aggregate with %v = %v, want just 10.0.0.0/8", p, got)
+    }
+  }
+
+  for _, bits := range []int{0, 32} {
+    if p := (IPPrefix{Bits: bits}); !p.IsValid() {
+      t.Errorf("%v.IsValid() = false", p)
+    }
+  }
+}
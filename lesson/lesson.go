@@ -0,0 +1,129 @@
+// Package lesson is a registry of runnable tour sections. Each lesson
+// package registers its sections in a package-level variable,
+//
+//   var Lesson = lesson.New("moretypes").Add("sliceOfSlices", sliceOfSlices)
+//
+// so importing the package is enough for its sections to be listed and
+// executed by name, e.g. "moretypes/sliceOfSlices".
+package lesson
+
+import (
+  "fmt"
+  "io"
+  "sort"
+  "strings"
+)
+
+// Section is a single runnable example within a lesson.
+type Section struct {
+  Lesson string
+  Name   string
+  Run    func(w io.Writer)
+}
+
+// Path returns the section's "lesson/section" name.
+func (s Section) Path() string {
+  return s.Lesson + "/" + s.Name
+}
+
+// Lesson is an ordered list of sections.
+type Lesson struct {
+  Name     string
+  Sections []Section
+}
+
+var registry = make(map[string]*Lesson)
+
+// New registers and returns an empty lesson called name. It panics if a
+// lesson with that name has already been registered.
+func New(name string) *Lesson {
+  if _, present := registry[name]; present {
+    panic("lesson: duplicate lesson " + name)
+  }
+
+  l := &Lesson{Name: name}
+  registry[name] = l
+  return l
+}
+
+// Add appends a section to l and returns l so calls can be chained.
+func (l *Lesson) Add(name string, run func(w io.Writer)) *Lesson {
+  if _, ok := l.Section(name); ok {
+    panic("lesson: duplicate section " + l.Name + "/" + name)
+  }
+
+  l.Sections = append(l.Sections, Section{Lesson: l.Name, Name: name, Run: run})
+  return l
+}
+
+// Section returns the section of l called name.
+func (l *Lesson) Section(name string) (Section, bool) {
+  for _, s := range l.Sections {
+    if s.Name == name {
+      return s, true
+    }
+  }
+  return Section{}, false
+}
+
+// Run executes every section of l in the order they were added, writing
+// their output to w.
+func (l *Lesson) Run(w io.Writer) {
+  for _, s := range l.Sections {
+    s.Run(w)
+  }
+}
+
+// Lookup returns the lesson registered as name.
+func Lookup(name string) (*Lesson, bool) {
+  l, ok := registry[name]
+  return l, ok
+}
+
+// All returns every registered lesson sorted by name.
+func All() []*Lesson {
+  lessons := make([]*Lesson, 0, len(registry))
+  for _, l := range registry {
+    lessons = append(lessons, l)
+  }
+
+  sort.Slice(lessons, func(i, j int) bool {
+    return lessons[i].Name < lessons[j].Name
+  })
+  return lessons
+}
+
+// Resolve finds the sections named by path, which is either a lesson name
+// or "lesson/section".
+func Resolve(path string) ([]Section, error) {
+  name, section, hasSection := strings.Cut(path, "/")
+
+  l, ok := Lookup(name)
+  if !ok {
+    return nil, fmt.Errorf("lesson: unknown lesson %q", name)
+  }
+
+  if !hasSection {
+    return l.Sections, nil
+  }
+
+  s, ok := l.Section(section)
+  if !ok {
+    return nil, fmt.Errorf("lesson: unknown section %q in lesson %q", section, name)
+  }
+  return []Section{s}, nil
+}
+
+// Run executes the sections named by path, as accepted by Resolve, writing
+// their output to w.
+func Run(w io.Writer, path string) error {
+  sections, err := Resolve(path)
+  if err != nil {
+    return err
+  }
+
+  for _, s := range sections {
+    s.Run(w)
+  }
+  return nil
+}
@@ -0,0 +1,116 @@
+package lesson
+
+import (
+  "io"
+  "strings"
+  "testing"
+)
+
+func nop(w io.Writer) {}
+
+func say(s string) func(io.Writer) {
+  return func(w io.Writer) { io.WriteString(w, s) }
+}
+
+// forget removes the named lessons from the registry when t finishes, so
+// tests can run more than once in the same process.
+func forget(t *testing.T, names ...string) {
+  t.Cleanup(func() {
+    for _, name := range names {
+      delete(registry, name)
+    }
+  })
+}
+
+func TestResolve(t *testing.T) {
+  forget(t, "resolve")
+  New("resolve").Add("one", say("1")).Add("two", say("2"))
+
+  tests := []struct {
+    path string
+    want []string // section paths, or nil if Resolve should fail
+    err  string
+  }{
+    {"resolve", []string{"resolve/one", "resolve/two"}, ""},
+    {"resolve/two", []string{"resolve/two"}, ""},
+    {"nosuch", nil, `lesson: unknown lesson "nosuch"`},
+    {"nosuch/one", nil, `lesson: unknown lesson "nosuch"`},
+    {"resolve/three", nil, `lesson: unknown section "three" in lesson "resolve"`},
+    {"resolve/", nil, `lesson: unknown section "" in lesson "resolve"`},
+  }
+  for _, tt := range tests {
+    sections, err := Resolve(tt.path)
+    if tt.want == nil {
+      if err == nil || err.Error() != tt.err {
+        t.Errorf("Resolve(%q) error = %v, want %s", tt.path, err, tt.err)
+      }
+      continue
+    }
+    if err != nil {
+      t.Errorf("Resolve(%q) error = %v", tt.path, err)
+      continue
+    }
+    var got []string
+    for _, s := range sections {
+      got = append(got, s.Path())
+    }
+    if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+      t.Errorf("Resolve(%q) = %v, want %v", tt.path, got, tt.want)
+    }
+  }
+}
+
+func TestRun(t *testing.T) {
+  forget(t, "run")
+  New("run").Add("a", say("a")).Add("b", say("b"))
+
+  var b strings.Builder
+  if err := Run(&b, "run"); err != nil || b.String() != "ab" {
+    t.Errorf("Run(run) wrote %q, %v, want %q", b.String(), err, "ab")
+  }
+  b.Reset()
+  if err := Run(&b, "run/b"); err != nil || b.String() != "b" {
+    t.Errorf("Run(run/b) wrote %q, %v, want %q", b.String(), err, "b")
+  }
+  if err := Run(&b, "run/c"); err == nil {
+    t.Errorf("Run(run/c) succeeded")
+  }
+}
+
+func TestDuplicates(t *testing.T) {
+  forget(t, "dup", "dup2")
+  New("dup").Add("s", nop)
+
+  tests := []struct {
+    name string
+    f    func()
+    want string
+  }{
+    {"lesson", func() { New("dup") }, "lesson: duplicate lesson dup"},
+    {"section", func() { New("dup2").Add("s", nop).Add("s", nop) }, "lesson: duplicate section dup2/s"},
+  }
+  for _, tt := range tests {
+    func() {
+      defer func() {
+        if got := recover(); got != tt.want {
+          t.Errorf("duplicate %s: panic = %v, want %q", tt.name, got, tt.want)
+        }
+      }()
+      tt.f()
+    }()
+  }
+}
+
+func TestAll(t *testing.T) {
+  forget(t, "zz-all", "aa-all")
+  New("zz-all")
+  New("aa-all")
+
+  var names []string
+  for _, l := range All() {
+    names = append(names, l.Name)
+  }
+  if !strings.HasPrefix(strings.Join(names, " "), "aa-all ") || names[len(names)-1] != "zz-all" {
+    t.Errorf("All() = %v, want names in sorted order", names)
+  }
+}
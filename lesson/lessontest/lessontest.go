@@ -0,0 +1,141 @@
+// Package lessontest provides golden-file testing for lessons. Each
+// section's output is compared with testdata/<section>.golden in the
+// lesson's package directory; run `go test -update` to rewrite them.
+package lessontest
+
+import (
+  "bytes"
+  "crypto/sha256"
+  "encoding/base64"
+  "flag"
+  "fmt"
+  "image/color"
+  "image/png"
+  "os"
+  "path/filepath"
+  "regexp"
+  "slices"
+  "strings"
+  "testing"
+
+  "github.com/lollar/gotour/lesson"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// A Filter rewrites the output of the named section before it is compared
+// with, or written to, its golden file. Filters hide output that varies
+// between toolchains or platforms.
+type Filter func(section string, out []byte) []byte
+
+// Golden runs every section of l as a subtest, comparing its output, after
+// applying filters, with the section's golden file.
+func Golden(t *testing.T, l *lesson.Lesson, filters ...Filter) {
+  t.Helper()
+
+  for _, s := range l.Sections {
+    t.Run(s.Name, func(t *testing.T) {
+      var b bytes.Buffer
+      s.Run(&b)
+      got := b.Bytes()
+      for _, f := range filters {
+        got = f(s.Name, got)
+      }
+
+      path := filepath.Join("testdata", s.Name+".golden")
+      if *update {
+        if err := os.MkdirAll("testdata", 0o755); err != nil {
+          t.Fatal(err)
+        }
+        if err := os.WriteFile(path, got, 0o644); err != nil {
+          t.Fatal(err)
+        }
+        return
+      }
+
+      want, err := os.ReadFile(path)
+      if err != nil {
+        t.Fatalf("%v (run go test -update to create it)", err)
+      }
+      if !bytes.Equal(got, want) {
+        t.Errorf("%s output differs from %s:\n%s", s.Path(), path, diff(string(want), string(got)))
+      }
+    })
+  }
+}
+
+// Replace returns a Filter that replaces matches of the regular expression
+// pattern with repl, as in regexp.ReplaceAll, in the output of the named
+// section.
+func Replace(section, pattern, repl string) Filter {
+  re := regexp.MustCompile(pattern)
+  return func(name string, out []byte) []byte {
+    if name != section {
+      return out
+    }
+    return re.ReplaceAll(out, []byte(repl))
+  }
+}
+
+// Images returns a Filter that rewrites the "IMAGE:" lines written by
+// pic.FshowImage in the named sections as the image size and a digest of its
+// pixels, so the golden files don't pin the PNG encoder's output.
+func Images(sections ...string) Filter {
+  return func(section string, out []byte) []byte {
+    if !slices.Contains(sections, section) {
+      return out
+    }
+    lines := strings.SplitAfter(string(out), "\n")
+    for i, line := range lines {
+      if data, ok := strings.CutPrefix(line, "IMAGE:"); ok {
+        lines[i] = "IMAGE:" + digest(strings.TrimSuffix(data, "\n")) + "\n"
+      }
+    }
+    return []byte(strings.Join(lines, ""))
+  }
+}
+
+// digest describes the base64 encoded PNG data by its size and the SHA-256
+// of its non-premultiplied RGBA pixels.
+func digest(data string) string {
+  b, err := base64.StdEncoding.DecodeString(data)
+  if err != nil {
+    return "invalid base64: " + err.Error()
+  }
+  m, err := png.Decode(bytes.NewReader(b))
+  if err != nil {
+    return "invalid PNG: " + err.Error()
+  }
+
+  h := sha256.New()
+  r := m.Bounds()
+  for y := r.Min.Y; y < r.Max.Y; y++ {
+    for x := r.Min.X; x < r.Max.X; x++ {
+      c := color.NRGBAModel.Convert(m.At(x, y)).(color.NRGBA)
+      h.Write([]byte{c.R, c.G, c.B, c.A})
+    }
+  }
+  return fmt.Sprintf("%dx%d sha256:%x", r.Dx(), r.Dy(), h.Sum(nil))
+}
+
+// diff compares want and got line by line, marking lines only in want with
+// "-" and lines only in got with "+".
+func diff(want, got string) string {
+  w := strings.Split(want, "\n")
+  g := strings.Split(got, "\n")
+
+  var b strings.Builder
+  for i := 0; i < len(w) || i < len(g); i++ {
+    switch {
+    case i >= len(g):
+      fmt.Fprintf(&b, "-%s\n", w[i])
+    case i >= len(w):
+      fmt.Fprintf(&b, "+%s\n", g[i])
+    case w[i] != g[i]:
+      fmt.Fprintf(&b, "-%s\n+%s\n", w[i], g[i])
+    default:
+      fmt.Fprintf(&b, " %s\n", w[i])
+    }
+  }
+  return b.String()
+}
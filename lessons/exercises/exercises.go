@@ -0,0 +1,93 @@
+// Package exercises collects the solutions to the tour's exercises, checked
+// with the offline harnesses in the tour directory.
+package exercises
+
+import (
+  "fmt"
+  "io"
+  "strings"
+
+  "github.com/lollar/gotour/ipaddr"
+  "github.com/lollar/gotour/lesson"
+  "github.com/lollar/gotour/picture"
+  "github.com/lollar/gotour/readers"
+  "github.com/lollar/gotour/roots"
+  "github.com/lollar/gotour/tour/pic"
+  "github.com/lollar/gotour/tour/reader"
+  "github.com/lollar/gotour/tour/wc"
+  "github.com/lollar/gotour/wordcount"
+)
+
+// Exercise: Loops and Functions
+func loops(w io.Writer) {
+  z, _ := roots.Sqrt(2)
+  fmt.Fprintln(w, z)
+}
+
+// Exercise: Slices
+func slices(w io.Writer) {
+  pic.Fshow(w, picture.Pic)
+}
+
+// Exercise: Maps
+func maps(w io.Writer) {
+  wc.Ftest(w, wordcount.WordCount)
+}
+
+// Exercise: Stringers
+func stringers(w io.Writer) {
+  // Map iteration order is random, so keep the hosts in a HostTable,
+  // which prints them sorted by name.
+  var hosts ipaddr.HostTable
+  hosts.Set("loopback", ipaddr.IPAddr{127, 0, 0, 1})
+  hosts.Set("googleDNS", ipaddr.IPAddr{8, 8, 8, 8})
+
+  for name, ip := range hosts.ByName() {
+    fmt.Fprintf(w, "%v: %v\n", name, ip)
+  }
+}
+
+// Exercise: Errors
+// Sqrt returns an ErrNegativeSqrt rather than a meaningless value when it
+// is given a negative number.
+func errorsExercise(w io.Writer) {
+  for _, x := range []float64{2, -2} {
+    z, err := roots.Sqrt(x)
+    fmt.Fprintln(w, z, err)
+  }
+}
+
+// Exercise: Images
+// Image implements image.Image, so pic.ShowImage can draw it directly.
+func images(w io.Writer) {
+  m := picture.Image{
+    W:       256,
+    H:       256,
+    Gen:     func(x, y int) uint8 { return uint8(x ^ y) },
+    Palette: picture.BluePalette,
+  }
+  pic.FshowImage(w, m)
+}
+
+// Exercise: Readers
+func readersExercise(w io.Writer) {
+  reader.Fvalidate(w, readers.MyReader{})
+}
+
+// Exercise: rot13Reader
+func rot13(w io.Writer) {
+  s := strings.NewReader("Lbh penpxrq gur pbqr!")
+  r := readers.Rot13Reader{R: s}
+  io.Copy(w, &r)
+  fmt.Fprintln(w)
+}
+
+var Lesson = lesson.New("exercises").
+  Add("loops", loops).
+  Add("slices", slices).
+  Add("maps", maps).
+  Add("stringers", stringers).
+  Add("errors", errorsExercise).
+  Add("readers", readersExercise).
+  Add("rot13", rot13).
+  Add("images", images)
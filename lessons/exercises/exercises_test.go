@@ -0,0 +1,11 @@
+package exercises
+
+import (
+  "testing"
+
+  "github.com/lollar/gotour/lesson/lessontest"
+)
+
+func TestGolden(t *testing.T) {
+  lessontest.Golden(t, Lesson, lessontest.Images("slices", "images"))
+}
@@ -1,32 +1,42 @@
-package main
+package flowcontrol
 
 import (
   "fmt"
+  "io"
   "math"
   "runtime"
   "time"
+
+  "github.com/lollar/gotour/lesson"
+)
+
+// The golden tests swap these out so the output doesn't depend on where
+// or when the lesson is run.
+var (
+  goos = runtime.GOOS
+  now  = time.Now
 )
 
 // For
 // The only looping construct in Go
 
-func forExample() {
+func forExample(w io.Writer) {
   sum := 0
   for i := 0; i < 10; i++ {
     sum += i
-    fmt.Println(sum)
+    fmt.Fprintln(w, sum)
   }
 }
 
 // For continued
 // The init and post statements are optional :)
 
-func forExampleCont() {
+func forExampleCont(w io.Writer) {
   sum := 1
   for ; sum < 1000; {
     sum += sum
   }
-  fmt.Println(sum)
+  fmt.Fprintln(w, sum)
 }
 
 // For is Go's "while"
@@ -53,11 +63,11 @@ func pow(x, n, lim float64) float64 {
   return lim
 }
 
-func powagain(x, n, lim float64) float64 {
+func powagain(w io.Writer, x, n, lim float64) float64 {
   if v := math.Pow(x, n); v < lim {
     return v
   } else {
-    fmt.Printf("%g >= %g\n", v, lim)
+    fmt.Fprintf(w, "%g >= %g\n", v, lim)
   }
   // can't access v here
   return lim
@@ -66,41 +76,49 @@ func powagain(x, n, lim float64) float64 {
 // Switch
 // Only runs the selected case not all cases that follow. No `break` is needed
 
-func switchySwitch() {
-  fmt.Print("Go runs on ")
-  switch os := runtime.GOOS; os {
+func switchySwitch(w io.Writer) {
+  fmt.Fprint(w, "Go runs on ")
+  switch os := goos; os {
   case "darwin":
-    fmt.Println("OS X.")
+    fmt.Fprintln(w, "OS X.")
   case "linux":
-    fmt.Println("Linux.")
+    fmt.Fprintln(w, "Linux.")
   default:
-    fmt.Printf("%s. \n", os)
+    fmt.Fprintf(w, "%s. \n", os)
   }
 }
 
-func untilSaturday() {
-  fmt.Println("\nWhen's Saturday?")
-  today := time.Now().Weekday()
+func untilSaturday(w io.Writer) {
+  fmt.Fprintln(w, "\nWhen's Saturday?")
+  today := now().Weekday()
 
   switch time.Saturday {
   case today + 0:
-    fmt.Println("Today.")
+    fmt.Fprintln(w, "Today.")
   case today + 1:
-    fmt.Println("Tomorrow.")
+    fmt.Fprintln(w, "Tomorrow.")
   case today + 2:
-    fmt.Println("In two days.")
+    fmt.Fprintln(w, "In two days.")
   default:
-    fmt.Println("Too far away. :(")
+    fmt.Fprintln(w, "Too far away. :(")
   }
-  fmt.Println("")
+  fmt.Fprintln(w, "")
 }
 
 // Defer
 // Defers the execution of a function until the surrounding function returns.
 // When defers are stacked they are executed in LIFO order
 
-func logger(x, y int) {
-  fmt.Println(x + y)
+func logger(w io.Writer, x, y int) {
+  fmt.Fprintln(w, x + y)
+}
+
+func deferSum(w io.Writer) {
+  x := 9
+  y := 10
+
+  defer logger(w, x, y)
+  fmt.Fprintf(w, "%d + %d = ", x, y)
 }
 
 // Defer, Panic, and Recover - Blog Post
@@ -117,47 +135,42 @@ func c() (i int) {
 
 // Recover regains control of a panicking goroutine. It's only useful inside deferred fuctions.
 
-func f() {
+func f(w io.Writer) {
   defer func() {
     if r := recover(); r != nil {
-      fmt.Println("recovered in f", r)
+      fmt.Fprintln(w, "recovered in f", r)
     }
   }()
-  fmt.Println("Calling g.")
-  g(0)
-  fmt.Println("Returned normally from g.")
+  fmt.Fprintln(w, "Calling g.")
+  g(w, 0)
+  fmt.Fprintln(w, "Returned normally from g.")
 }
 
-func g(i int) {
+func g(w io.Writer, i int) {
   if i > 3 {
-    fmt.Println("Panicking!")
+    fmt.Fprintln(w, "Panicking!")
     panic(fmt.Sprintf("%v", i))
   }
 
-  defer fmt.Println("Defer in g", i)
-  fmt.Println("Printing in g", i)
-  g(i + 1)
+  defer fmt.Fprintln(w, "Defer in g", i)
+  fmt.Fprintln(w, "Printing in g", i)
+  g(w, i + 1)
 }
 
-// Add methods from each section here to execute code
-func main() {
-  forExample()
-  forExampleCont()
-  fmt.Println(sqrt(16), sqrt(-4))
-  fmt.Println(pow(3,2,10), pow(3,3,20))
-  fmt.Println(powagain(3,2,10), powagain(3,3,20))
-  switchySwitch()
-  untilSaturday()
-
-  x := 9
-  y := 10
-
-  fmt.Println(c())
-
-  f()
-  fmt.Println("Returned from f normally.")
-
-  // Defer section (has to run last)
-  defer logger(x, y)
-  fmt.Printf("%d + %d = ", x, y)
+func recovering(w io.Writer) {
+  f(w)
+  fmt.Fprintln(w, "Returned from f normally.")
 }
+
+// Add methods from each section here to execute code
+var Lesson = lesson.New("flowcontrol").
+  Add("forExample", forExample).
+  Add("forExampleCont", forExampleCont).
+  Add("sqrt", func(w io.Writer) { fmt.Fprintln(w, sqrt(16), sqrt(-4)) }).
+  Add("pow", func(w io.Writer) { fmt.Fprintln(w, pow(3,2,10), pow(3,3,20)) }).
+  Add("powagain", func(w io.Writer) { fmt.Fprintln(w, powagain(w, 3,2,10), powagain(w, 3,3,20)) }).
+  Add("switchySwitch", switchySwitch).
+  Add("untilSaturday", untilSaturday).
+  Add("c", func(w io.Writer) { fmt.Fprintln(w, c()) }).
+  Add("f", recovering).
+  Add("deferSum", deferSum)
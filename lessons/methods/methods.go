@@ -1,4 +1,4 @@
-package main
+package methods
 
 import (
   "fmt"
@@ -6,18 +6,29 @@ import (
   "time"
   "strings"
   "io"
+
+  "github.com/lollar/gotour/geom"
+  "github.com/lollar/gotour/lesson"
 )
 
-// Methods
-// Go does not have classes but you can define methods on types
-// receive appears in it's own argument list
+// Methods, pointer receivers, and pointers and functions
+// See the Vertex type in the geom package
+
+func methods(w io.Writer) {
+  v := geom.Vertex{X: 3, Y: 4}
+  fmt.Fprintln(w, v.Abs())
+}
 
-type Vertex struct {
-  X, Y float64
+func pointerReceivers(w io.Writer) {
+  v := geom.Vertex{X: 3, Y: 4}
+  v.Scale(10)
+  fmt.Fprintln(w, v.Abs())
 }
 
-func (v Vertex) Abs() float64 {
-  return math.Sqrt(v.X * v.X + v.Y * v.Y)
+func pointersAndFunctions(w io.Writer) {
+  v := geom.Vertex{X: 3, Y: 4}
+  geom.ScaleFunc(&v, 10)
+  fmt.Fprintln(w, geom.AbsFunc(v))
 }
 
 // Methods continued
@@ -33,28 +44,9 @@ func (f MyFloat) FloatAbs() float64 {
   return float64(f)
 }
 
-// Pointer receivers
-// Methods can be declared with pointer recievers. Meaning the receiver type
-// has the literal syntax *T where T cannot also be a pointer.  Methods with
-// pointer receivers can modify the value. With a value receiver, a method
-// operates on a copy of the original value. Pointer receivers must be used
-// to operate on the original declared value being passed to the function
-
-func (v *Vertex) Scale(f float64) {
-  v.X = v.X * f
-  v.Y = v.Y * f
-}
-
-// Pointers and functions
-// Pointers can also be used in an argument list for a function
-
-func AbsFunc(v Vertex) float64 {
-  return math.Sqrt(v.X * v.X + v.Y * v.Y)
-}
-
-func ScaleFunc(v *Vertex, f float64) {
-  v.X = v.X * f
-  v.Y = v.Y * f
+func methodsContinued(w io.Writer) {
+  f := MyFloat(-math.Sqrt2)
+  fmt.Fprintln(w, f.FloatAbs())
 }
 
 // Choosing a value or pointer receiver
@@ -81,14 +73,19 @@ func ScaleFunc(v *Vertex, f float64) {
 // "implements" keyword is needed.
 
 type I interface {
-  M()
+  M(w io.Writer)
 }
 
 type T struct {
   S string
 }
-func (t *T) M() {
-  fmt.Println(t.S)
+func (t *T) M(w io.Writer) {
+  fmt.Fprintln(w, t.S)
+}
+
+func interfaces(w io.Writer) {
+  var i I = &T{"hello"}
+  i.M(w)
 }
 
 // Interface values
@@ -96,12 +93,24 @@ func (t *T) M() {
 
 type F float64
 
-func (f F) M() {
-  fmt.Println(f)
+func (f F) M(w io.Writer) {
+  fmt.Fprintln(w, f)
 }
 
-func describe(i I) {
-  fmt.Printf("(%v, %T)\n", i, i)
+func describe(w io.Writer, i I) {
+  fmt.Fprintf(w, "(%v, %T)\n", i, i)
+}
+
+func interfaceValues(w io.Writer) {
+  var j I
+
+  j = &T{"Hello"}
+  describe(w, j)
+  j.M(w)
+
+  j = F(math.Pi)
+  describe(w, j)
+  j.M(w)
 }
 
 // Interface values with nil underlying values
@@ -120,17 +129,17 @@ func describe(i I) {
 // `t, ok := i.(T)` // this assigns the underlying value and a boolean value
 // that reports wheter the assertion succeeded.
 
-func typeAssertions() {
+func typeAssertions(w io.Writer) {
   var i interface{} = "hello"
 
   s := i.(string)
-  fmt.Println(s)
+  fmt.Fprintln(w, s)
 
   s, ok := i.(string)
-  fmt.Println(s)
+  fmt.Fprintln(w, s)
 
   f, ok := i.(float64)
-  fmt.Println(f, ok)
+  fmt.Fprintln(w, f, ok)
 
   // panic!
   // f = i.(float64)
@@ -140,21 +149,21 @@ func typeAssertions() {
 // Construct that permits several type assertions in series.
 // Like a case statement but specify types not values
 
-func typeChecker(i interface{}) {
+func typeChecker(w io.Writer, i interface{}) {
   switch v := i.(type) {
   case int:
-    fmt.Printf("int: %v\n", v)
+    fmt.Fprintf(w, "int: %v\n", v)
   case string:
-    fmt.Printf("string: %q\n", v)
+    fmt.Fprintf(w, "string: %q\n", v)
   default:
-    fmt.Printf("man... I don't know %T\n", v)
+    fmt.Fprintf(w, "man... I don't know %T\n", v)
   }
 }
 
-func do() {
-  typeChecker(21)
-  typeChecker("hello")
-  typeChecker(true)
+func do(w io.Writer) {
+  typeChecker(w, 21)
+  typeChecker(w, "hello")
+  typeChecker(w, true)
 }
 
 type Person struct {
@@ -166,6 +175,12 @@ func (p Person) String() string {
   return fmt.Sprintf("%v (%v years)", p.Name, p.Age)
 }
 
+func stringers(w io.Writer) {
+  a := Person{"Arthur Dent", 42}
+  b := Person{"Bill Clinton", 67}
+  fmt.Fprintln(w, a,b)
+}
+
 // Errors
 // Error state expressed with `error` values. The `error` type is a
 // built-in interface. A nil `error` denotes success.
@@ -178,26 +193,35 @@ func (e *MyError) Error() string {
   return fmt.Sprintf("at %v, %s", e.When, e.What)
 }
 
+// Replaced by the golden tests so the error message is reproducible.
+var now = time.Now
+
 func run() error {
   return &MyError {
-    time.Now(),
+    now(),
     "you fucked up",
   }
 }
 
+func runError(w io.Writer) {
+  if err := run(); err != nil {
+    fmt.Fprintln(w, err)
+  }
+}
+
 // Readers
 // the `io` package specifies the `io.Reader` interface
 // the following code creates a `strings.Reader` and consumes
 // its output 8 bytes at a time.
-func readersExample() {
+func readersExample(w io.Writer) {
   r := strings.NewReader("Hello, Reader!")
 
   b := make([]byte, 8)
 
   for {
     n, err := r.Read(b)
-    fmt.Printf("n = %v err = %v b = %v\n", n, err, b)
-    fmt.Printf("b[:n] = %q\n", b[:n])
+    fmt.Fprintf(w, "n = %v err = %v b = %v\n", n, err, b)
+    fmt.Fprintf(w, "b[:n] = %q\n", b[:n])
     if err == io.EOF {
       break
     }
@@ -205,42 +229,15 @@ func readersExample() {
 }
 
 // Add methods from each section here to execute code
-func main() {
-  v := Vertex{3, 4}
-  fmt.Println(v.Abs())
-  f := MyFloat(-math.Sqrt2)
-  fmt.Println(f.FloatAbs())
-
-  v.Scale(10)
-  fmt.Println(v.Abs())
-
-  v2 := Vertex{3,4}
-  ScaleFunc(&v2, 10)
-  fmt.Println(AbsFunc(v2))
-
-  var i I = &T{"hello"}
-  i.M()
-
-  var j I
-
-  j = &T{"Hello"}
-  describe(j)
-  j.M()
-
-  j = F(math.Pi)
-  describe(j)
-  j.M()
-
-  typeAssertions()
-  do()
-
-  a := Person{"Arthur Dent", 42}
-  b := Person{"Bill Clinton", 67}
-  fmt.Println(a,b)
-
-  if err := run(); err != nil {
-    fmt.Println(err)
-  }
-
-  readersExample()
-}
+var Lesson = lesson.New("methods").
+  Add("methods", methods).
+  Add("methodsContinued", methodsContinued).
+  Add("pointerReceivers", pointerReceivers).
+  Add("pointersAndFunctions", pointersAndFunctions).
+  Add("interfaces", interfaces).
+  Add("interfaceValues", interfaceValues).
+  Add("typeAssertions", typeAssertions).
+  Add("typeSwitches", do).
+  Add("stringers", stringers).
+  Add("errors", runError).
+  Add("readersExample", readersExample)
@@ -0,0 +1,16 @@
+package methods
+
+import (
+  "testing"
+  "time"
+
+  "github.com/lollar/gotour/lesson/lessontest"
+)
+
+func TestGolden(t *testing.T) {
+  now = func() time.Time {
+    return time.Date(2019, time.November, 23, 12, 0, 0, 0, time.UTC)
+  }
+
+  lessontest.Golden(t, Lesson)
+}
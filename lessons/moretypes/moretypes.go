@@ -1,25 +1,28 @@
-package main
+package moretypes
 
 import(
   "fmt"
+  "io"
   "math"
   "strings"
+
+  "github.com/lollar/gotour/lesson"
 )
 
 // Pointers
 // Zero value is nil, the `&` operatores generates a pointer to its operand
 // The `*` operator denotes the pointer's underlying value
-func pointers() {
+func pointers(w io.Writer) {
   i, j := 42, 2701
 
   p := &i          // point to i
-  fmt.Println(*p)  // read i through the pointer
+  fmt.Fprintln(w, *p)  // read i through the pointer
   *p = 21          // set i through the pointer
-  fmt.Println(i)   // see the new value of i
+  fmt.Fprintln(w, i)   // see the new value of i
 
   p = &j           // point to j
   *p = *p / 37     // divide j through the pointer
-  fmt.Println(j)   // see the new value of j
+  fmt.Fprintln(w, j)   // see the new value of j
 }
 
 // Structs
@@ -28,22 +31,26 @@ type Vertex struct {
   Y int
 }
 
+func structs(w io.Writer) {
+  fmt.Fprintln(w, Vertex{1,2})
+}
+
 // Struct Fields
 // Accessed using a `.`
-func vertex() {
+func vertex(w io.Writer) {
   v := Vertex{1,2}
   v.X = 4
-  fmt.Println(v.X)
+  fmt.Fprintln(w, v.X)
 }
 
 // Pointers to structs
 // Fields can be added via a pointer
 // long hand notation `(*p).X` but the language allows us to write `p.X`
-func pointersToStructs() {
+func pointersToStructs(w io.Writer) {
   v := Vertex{1,2}
   p := &v
   p.X = 1e9
-  fmt.Println(v)
+  fmt.Fprintln(w, v)
 }
 
 // Struct Literals
@@ -56,19 +63,23 @@ var(
   p  = &Vertex{1, 2}
 )
 
+func structLiterals(w io.Writer) {
+  fmt.Fprintln(w, v1, p, v2, v3)
+}
+
 // Arrays
 // Denoted as `[n]T` where n is size and T is type
 // Arrays length is part of it's type so cannot be resized
 
-func arr() {
+func arr(w io.Writer) {
   var a [2]string
   a[0] = "Hello"
   a[1] = "World"
-  fmt.Println(a[0], a[1])
-  fmt.Println(a)
+  fmt.Fprintln(w, a[0], a[1])
+  fmt.Fprintln(w, a)
 
   primes := [6]int{2, 3, 5, 7, 11, 13}
-  fmt.Println(primes)
+  fmt.Fprintln(w, primes)
 }
 
 // Slices
@@ -78,35 +89,35 @@ func arr() {
 // describes the defined section of an array. Changing elements of a
 // slice will update the corresponding elements of the array.
 
-func slices() {
+func slices(w io.Writer) {
   primes := [6]int{2, 3, 5, 7, 11, 13}
 
   var s []int = primes[1:4]
-  fmt.Println(s)
+  fmt.Fprintln(w, s)
 }
 
-func arrSlice() {
+func arrSlice(w io.Writer) {
   names := [4]string{ "John", "Paul", "George", "Ringo" }
-  fmt.Println(names)
+  fmt.Fprintln(w, names)
 
   a := names[0:2]
   b := names[1:3]
-  fmt.Println(a, b)
+  fmt.Fprintln(w, a, b)
 
   b[0] = "XXX"
-  fmt.Println(a, b)
-  fmt.Println(names)
+  fmt.Fprintln(w, a, b)
+  fmt.Fprintln(w, names)
 }
 
 // Slice literals
 // Creates the same array, the builds a slice that references it
 
-func literallySliced() {
+func literallySliced(w io.Writer) {
   q := []int{2,3,5,7,11,13}
-  fmt.Println(q)
+  fmt.Fprintln(w, q)
 
   r := []bool{true, false, true, true, false, true}
-  fmt.Println(r)
+  fmt.Fprintln(w, r)
 
   s := []struct {
     i int
@@ -119,24 +130,24 @@ func literallySliced() {
     {11, false},
     {13, true},
   }
-  fmt.Println(s)
+  fmt.Fprintln(w, s)
 }
 
 // Slice defaults
 // can omit the high or low bounds to use defaults instead
 // defaults: low bound - 0 & high bound - length of slice
 
-func sliceDefaults() {
+func sliceDefaults(w io.Writer) {
   s := []int{2,3,5,7,11,13}
 
   s = s[:]
-  fmt.Println(s)
+  fmt.Fprintln(w, s)
 
   s = s[:2]
-  fmt.Println(s)
+  fmt.Fprintln(w, s)
 
   s = s[1:]
-  fmt.Println(s)
+  fmt.Fprintln(w, s)
 
 }
 
@@ -152,28 +163,28 @@ func sliceDefaults() {
 // Slices can be created with the built-in fuction `make`. This is how you create dynamically-sized arrays.
 // `make` allocates a zeroed array and returns a slice that refers to the array.
 // `a := make([]int, 5) // len(a) = 5
-func makeSlice() {
+func makeSlice(w io.Writer) {
   a := make([]int, 5)
-  printSlice("a", a)
+  printSlice(w, "a", a)
 
   b := make([]int, 0, 5)
-  printSlice("b", b)
+  printSlice(w, "b", b)
 
   c := b[:2]
-  printSlice("c", c)
+  printSlice(w, "c", c)
 
   d := c[2:5]
-  printSlice("d", d)
+  printSlice(w, "d", d)
 }
 
-func printSlice(s string, x []int) {
-	fmt.Printf("%s len=%d cap=%d %v\n",
+func printSlice(w io.Writer, s string, x []int) {
+	fmt.Fprintf(w, "%s len=%d cap=%d %v\n",
 		s, len(x), cap(x), x)
 }
 
 // Slice of slices
 // Slices can contain any type, including other slices
-func sliceOfSlices() {
+func sliceOfSlices(w io.Writer) {
   board := [][]string{
     []string{"_", "_", "_"},
     []string{"_", "_", "_"},
@@ -187,7 +198,7 @@ func sliceOfSlices() {
   board[0][2] = "X"
 
   for i := 0; i < len(board); i++ {
-    fmt.Printf("%s\n", strings.Join(board[i], " "))
+    fmt.Fprintf(w, "%s\n", strings.Join(board[i], " "))
   }
 }
 
@@ -195,16 +206,16 @@ func sliceOfSlices() {
 // Use built-in append function. First param is a slice of type T,
 // the rest of the params are T values to append. A newly allocated
 // array will be provided if original array is too small
-func appendToSlice() {
+func appendToSlice(w io.Writer) {
   var s []int
-  printSlice("s", s)
+  printSlice(w, "s", s)
 
   // append works on nil slices
   s = append(s, 0)
-  printSlice("nil slice", s)
+  printSlice(w, "nil slice", s)
 
   s = append(s, 2, 3, 4)
-  printSlice("more than one", s)
+  printSlice(w, "more than one", s)
 }
 
 // Range
@@ -212,10 +223,10 @@ func appendToSlice() {
 // When ranging over a slice two values are returned the
 // index, and the underlying value
 
-func ranging() {
+func ranging(w io.Writer) {
   var pow = []int{1, 2, 4, 8, 16, 32, 64, 128}
   for i, v := range pow {
-    fmt.Printf("2**%d = %d\n", i, v)
+    fmt.Fprintf(w, "2**%d = %d\n", i, v)
   }
 }
 
@@ -223,14 +234,14 @@ func ranging() {
 // You can skip index or value by assigning to `_`
 // And if you only want index you can omit the second variable
 
-func ratm() {
+func ratm(w io.Writer) {
   pow := make([]int, 10)
   for i := range pow {
     pow[i] = 1 << uint(i)
   }
 
   for _, value := range pow {
-    fmt.Printf("%d\n", value)
+    fmt.Fprintf(w, "%d\n", value)
   }
 }
 
@@ -242,12 +253,12 @@ type Coordinates struct {
   Lat, Long float64
 }
 
-func mappyMap() {
+func mappyMap(w io.Writer) {
   m := make(map[string]Coordinates)
   m["Bell Labs"] = Coordinates{
     40.68433, -74.39967,
   }
-  fmt.Println(m["Bell Labs"])
+  fmt.Fprintln(w, m["Bell Labs"])
 }
 
 // Map literals
@@ -263,8 +274,8 @@ var ma = map[string]Coordinates{
 	},
 }
 
-func printCoordinates() {
-  fmt.Println(ma)
+func printCoordinates(w io.Writer) {
+  fmt.Fprintln(w, ma)
 }
 
 // Mutating maps
@@ -273,20 +284,20 @@ func printCoordinates() {
 // Deletion  `delete(m, key)`
 // Presence  `elem, ok := m[key]`
 
-func mapMutation() {
+func mapMutation(w io.Writer) {
   m := make(map[string]int)
 
   m["Answer"] = 42
-  fmt.Println("Value:", m["Answer"])
+  fmt.Fprintln(w, "Value:", m["Answer"])
 
   m["Answer"] = 48
-  fmt.Println("Value:", m["Answer"])
+  fmt.Fprintln(w, "Value:", m["Answer"])
 
   delete(m, "Answer")
-  fmt.Println("Value:", m["Answer"])
+  fmt.Fprintln(w, "Value:", m["Answer"])
 
   v, ok := m["Answer"]
-  fmt.Println("Value:", v, "Present?", ok)
+  fmt.Fprintln(w, "Value:", v, "Present?", ok)
 }
 
 // Function values
@@ -297,15 +308,15 @@ func compute(fn func(float64, float64) float64) float64 {
   return fn(3, 4)
 }
 
-func functioning() {
+func functioning(w io.Writer) {
   hypot := func(x, y float64) float64 {
     return math.Sqrt(x*x + y*y)
   }
 
-  fmt.Println(hypot(5, 12))
+  fmt.Fprintln(w, hypot(5, 12))
 
-  fmt.Println(compute(hypot))
-  fmt.Println(compute(math.Pow))
+  fmt.Fprintln(w, compute(hypot))
+  fmt.Fprintln(w, compute(math.Pow))
 }
 
 // Function closures
@@ -320,35 +331,33 @@ func adder() func(int) int {
   }
 }
 
-func adderCaller() {
+func adderCaller(w io.Writer) {
   pos, neg := adder(), adder()
 
   for i := 0; i < 10; i++ {
-    fmt.Println(pos(i), neg(-2*i))
+    fmt.Fprintln(w, pos(i), neg(-2*i))
   }
 }
 
 // Add methods from each section here to execute code
-func main() {
-  pointers()
-  fmt.Println(Vertex{1,2})
-  vertex()
-  pointersToStructs()
-  fmt.Println(v1, p, v2, v3)
-  arr()
-  slices()
-  arrSlice()
-  fmt.Println("\n")
-  literallySliced()
-  sliceDefaults()
-  makeSlice()
-  sliceOfSlices()
-  appendToSlice()
-  ranging()
-  ratm()
-  mappyMap()
-  printCoordinates()
-  mapMutation()
-  functioning()
-  adderCaller()
-}
+var Lesson = lesson.New("moretypes").
+  Add("pointers", pointers).
+  Add("structs", structs).
+  Add("vertex", vertex).
+  Add("pointersToStructs", pointersToStructs).
+  Add("structLiterals", structLiterals).
+  Add("arr", arr).
+  Add("slices", slices).
+  Add("arrSlice", arrSlice).
+  Add("literallySliced", literallySliced).
+  Add("sliceDefaults", sliceDefaults).
+  Add("makeSlice", makeSlice).
+  Add("sliceOfSlices", sliceOfSlices).
+  Add("appendToSlice", appendToSlice).
+  Add("ranging", ranging).
+  Add("ratm", ratm).
+  Add("mappyMap", mappyMap).
+  Add("printCoordinates", printCoordinates).
+  Add("mapMutation", mapMutation).
+  Add("functioning", functioning).
+  Add("adderCaller", adderCaller)
@@ -0,0 +1,13 @@
+package moretypes
+
+import (
+  "testing"
+
+  "github.com/lollar/gotour/lesson/lessontest"
+)
+
+func TestGolden(t *testing.T) {
+  // The capacity append picks depends on the allocator's size classes,
+  // which differ between architectures.
+  lessontest.Golden(t, Lesson, lessontest.Replace("appendToSlice", `cap=\d+`, "cap=N"))
+}
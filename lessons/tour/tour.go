@@ -1,23 +1,29 @@
 // Getting Started
-package main
+package tour
 
 import(
   "fmt"
+  "io"
   "math"
   "math/rand"
   "math/cmplx"
+
+  "github.com/lollar/gotour/lesson"
 )
 
-func helloWorld() {
-  fmt.Printf("hello, world\n")
+func helloWorld(w io.Writer) {
+  fmt.Fprintf(w, "hello, world\n")
 }
 
 // Package
 // The Package name is the same as the last element of the import path
 // i.e. "math/rand" (see import above) is made up of files with `package rand`
 
-func randomNumber() {
-  fmt.Println("My favorite number is", rand.Intn(10))
+// Replaced by the golden tests so the favorite number is reproducible.
+var intn = rand.Intn
+
+func randomNumber(w io.Writer) {
+  fmt.Fprintln(w, "My favorite number is", intn(10))
 }
 
 // Imports
@@ -28,8 +34,8 @@ func randomNumber() {
 //
 // But the factored import statements are considered good style.
 
-func myProblems() {
-  fmt.Printf("Now you have %g problems. \n", math.Sqrt(7))
+func myProblems(w io.Writer) {
+  fmt.Fprintf(w, "Now you have %g problems. \n", math.Sqrt(7))
 }
 
 // Exported names
@@ -77,21 +83,31 @@ func split(sum int) (x, y int) {
 
 var c, python, java bool
 
+func variables(w io.Writer) {
+  var i, j int
+  fmt.Fprintln(w, i,j,c,python,java)
+}
+
 // Variables with Initializers
-// See example in main below
+// See example in variablesWithInitializers below
 
 var k, l int = 1, 2
 
+func variablesWithInitializers(w io.Writer) {
+  var golang, ruby, perl = true, false, "hell no!"
+  fmt.Fprintln(w, k, l, golang, ruby, perl)
+}
+
 // Short variable declarations
 // The `:=` symbol can be used in place of `var` with implicit type in a function body.
 // Outside of a function it is not available.
 
-func shortVariable() {
+func shortVariable(w io.Writer) {
   var i, j int = 1, 2
   k := 3
   c, python, java := true, false, "no!"
 
-  fmt.Println(i,j,k,c,python,java)
+  fmt.Fprintln(w, i,j,k,c,python,java)
 }
 
 // Basic Types
@@ -106,32 +122,32 @@ var (
   z      complex128 = cmplx.Sqrt(-5 + 12i)
 )
 
-func printBasicTypes() {
-  fmt.Printf("Type: %T Value: %v\n", ToBe, ToBe)
-  fmt.Printf("Type: %T Value: %v\n", MaxInt, MaxInt)
-  fmt.Printf("Type: %T Value: %v\n", z, z)
+func printBasicTypes(w io.Writer) {
+  fmt.Fprintf(w, "Type: %T Value: %v\n", ToBe, ToBe)
+  fmt.Fprintf(w, "Type: %T Value: %v\n", MaxInt, MaxInt)
+  fmt.Fprintf(w, "Type: %T Value: %v\n", z, z)
 }
 
 // Zero values
 // When a variable is declared without an inital value it will be given it's "zero value"
 
-func zeroValues() {
+func zeroValues(w io.Writer) {
   var i int     // 0
   var f float64 // 0
   var b bool    // false
   var s string  // ""
-  fmt.Printf("%v %v %v %q\n", i, f, b, s)
+  fmt.Fprintf(w, "%v %v %v %q\n", i, f, b, s)
 }
 
 // Type conversions
 // T(v) converts value `v` to type `T`
 
-func typeConversions() {
+func typeConversions(w io.Writer) {
   x, y := 3, 4
   f    := math.Sqrt(float64(x*x + y*y))
   z    := uint(f)
 
-  fmt.Println(x, y, z)
+  fmt.Fprintln(w, x, y, z)
 }
 
 // Type inference
@@ -143,13 +159,13 @@ func typeConversions() {
 
 const MyPi = 3.14
 
-func tourOfConstants() {
+func tourOfConstants(w io.Writer) {
   const World = "世界"
-  fmt.Println("Hello", World)
-  fmt.Println("Happy", MyPi, "Day")
+  fmt.Fprintln(w, "Hello", World)
+  fmt.Fprintln(w, "Happy", MyPi, "Day")
 
   const Truth = true
-  fmt.Println("Go rules?", Truth)
+  fmt.Fprintln(w, "Go rules?", Truth)
 }
 
 // Numeric Constants
@@ -163,33 +179,31 @@ const (
 func needInt(x int) int { return x * 10 + 1 }
 func needFloat(x float64) float64 { return x * 0.1 }
 
-func printNumConsts() {
-  fmt.Println(needInt(Small))
-  fmt.Println(needFloat(Small))
-  fmt.Println(needFloat(Big))
+func printNumConsts(w io.Writer) {
+  fmt.Fprintln(w, needInt(Small))
+  fmt.Fprintln(w, needFloat(Small))
+  fmt.Fprintln(w, needFloat(Big))
 }
 
 // Add methods from each section here to execute code
-func main() {
-  helloWorld()
-  randomNumber()
-  myProblems()
-  fmt.Println(goAdd(42,13))
-  fmt.Println(lastNameFirst("mike", "lollar"))
-  fmt.Println(split(17))
-
-  // Variables example
-  var i, j int
-  fmt.Println(i,j,c,python,java)
-
-  // Variables with Initializers
-  var golang, ruby, perl = true, false, "hell no!"
-  fmt.Println(k, l, golang, ruby, perl)
-
-  shortVariable()
-  printBasicTypes()
-  zeroValues()
-  typeConversions()
-  tourOfConstants()
-  printNumConsts()
-}
+var Lesson = lesson.New("tour").
+  Add("helloWorld", helloWorld).
+  Add("randomNumber", randomNumber).
+  Add("myProblems", myProblems).
+  Add("goAdd", func(w io.Writer) { fmt.Fprintln(w, goAdd(42,13)) }).
+  Add("lastNameFirst", func(w io.Writer) {
+    last, first := lastNameFirst("mike", "lollar")
+    fmt.Fprintln(w, last, first)
+  }).
+  Add("split", func(w io.Writer) {
+    x, y := split(17)
+    fmt.Fprintln(w, x, y)
+  }).
+  Add("variables", variables).
+  Add("variablesWithInitializers", variablesWithInitializers).
+  Add("shortVariable", shortVariable).
+  Add("printBasicTypes", printBasicTypes).
+  Add("zeroValues", zeroValues).
+  Add("typeConversions", typeConversions).
+  Add("tourOfConstants", tourOfConstants).
+  Add("printNumConsts", printNumConsts)
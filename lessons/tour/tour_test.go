@@ -0,0 +1,13 @@
+package tour
+
+import (
+  "testing"
+
+  "github.com/lollar/gotour/lesson/lessontest"
+)
+
+func TestGolden(t *testing.T) {
+  intn = func(int) int { return 7 }
+
+  lessontest.Golden(t, Lesson)
+}
@@ -0,0 +1,88 @@
+package picture
+
+import (
+  "bufio"
+  "fmt"
+  "image"
+  "image/color"
+  "image/gif"
+  "image/png"
+  "io"
+  "path/filepath"
+  "strings"
+)
+
+// Gray converts the rows returned by a Pic function into a grayscale image,
+// dx wide and dy tall. Every row must be the same length.
+func Gray(data [][]uint8) (*image.Gray, error) {
+  dx, dy, err := size(data)
+  if err != nil {
+    return nil, err
+  }
+
+  m := image.NewGray(image.Rect(0, 0, dx, dy))
+  for y, row := range data {
+    copy(m.Pix[y*m.Stride:], row)
+  }
+  return m, nil
+}
+
+// Formats lists the formats Encode accepts.
+var Formats = []string{"png", "gif", "pgm"}
+
+// FormatOf returns the format implied by the extension of path, such as
+// "png" for "out.png", or "" if the extension is not one of Formats.
+func FormatOf(path string) string {
+  ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+  for _, f := range Formats {
+    if ext == f {
+      return f
+    }
+  }
+  return ""
+}
+
+// Encode writes data to w as a grayscale image in the named format, one
+// of Formats.
+func Encode(w io.Writer, data [][]uint8, format string) error {
+  m, err := Gray(data)
+  if err != nil {
+    return err
+  }
+
+  switch format {
+  case "png":
+    return png.Encode(w, m)
+  case "gif":
+    return gif.Encode(w, paletted(m), nil)
+  case "pgm":
+    return EncodePGM(w, m)
+  }
+  return fmt.Errorf("picture: unknown format %q", format)
+}
+
+// EncodePGM writes m as a binary ("P5") portable graymap.
+func EncodePGM(w io.Writer, m *image.Gray) error {
+  bw := bufio.NewWriter(w)
+  b := m.Bounds()
+  fmt.Fprintf(bw, "P5\n%d %d\n255\n", b.Dx(), b.Dy())
+
+  for y := b.Min.Y; y < b.Max.Y; y++ {
+    i := m.PixOffset(b.Min.X, y)
+    bw.Write(m.Pix[i : i+b.Dx()])
+  }
+  return bw.Flush()
+}
+
+// paletted converts m to a paletted image with all 256 shades of gray, so
+// the GIF encoder doesn't need to quantize or dither it.
+func paletted(m *image.Gray) *image.Paletted {
+  p := make(color.Palette, 256)
+  for i := range p {
+    p[i] = color.Gray{Y: uint8(i)}
+  }
+
+  pm := image.NewPaletted(m.Bounds(), p)
+  copy(pm.Pix, m.Pix)
+  return pm
+}
@@ -0,0 +1,73 @@
+package picture
+
+import (
+  "bytes"
+  "image"
+  "image/gif"
+  "image/png"
+  "testing"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+  data := Pic(64, 48)
+
+  for _, format := range []string{"png", "gif"} {
+    var buf bytes.Buffer
+    if err := Encode(&buf, data, format); err != nil {
+      t.Fatalf("Encode %s: %v", format, err)
+    }
+
+    var m image.Image
+    var err error
+    if format == "png" {
+      m, err = png.Decode(&buf)
+    } else {
+      m, err = gif.Decode(&buf)
+    }
+    if err != nil {
+      t.Fatalf("decoding %s: %v", format, err)
+    }
+
+    if b := m.Bounds(); b.Dx() != 64 || b.Dy() != 48 {
+      t.Fatalf("%s image is %dx%d, want 64x48", format, b.Dx(), b.Dy())
+    }
+    for y := range data {
+      for x, v := range data[y] {
+        if r, _, _, _ := m.At(x, y).RGBA(); uint8(r>>8) != v {
+          t.Fatalf("%s pixel (%d, %d) = %d, want %d", format, x, y, r>>8, v)
+        }
+      }
+    }
+  }
+}
+
+func TestEncodePGM(t *testing.T) {
+  var buf bytes.Buffer
+  if err := Encode(&buf, [][]uint8{{0, 1, 2}, {253, 254, 255}}, "pgm"); err != nil {
+    t.Fatal(err)
+  }
+
+  want := append([]byte("P5\n3 2\n255\n"), 0, 1, 2, 253, 254, 255)
+  if !bytes.Equal(buf.Bytes(), want) {
+    t.Errorf("PGM = %q, want %q", buf.Bytes(), want)
+  }
+}
+
+func TestEncodeErrors(t *testing.T) {
+  if err := Encode(new(bytes.Buffer), [][]uint8{{1, 2}, {3}}, "png"); err == nil {
+    t.Error("Encode accepted ragged rows")
+  }
+  if err := Encode(new(bytes.Buffer), Pic(2, 2), "bmp"); err == nil {
+    t.Error("Encode accepted an unknown format")
+  }
+}
+
+func TestFormatOf(t *testing.T) {
+  for path, want := range map[string]string{
+    "out.png": "png", "a/b.GIF": "gif", "x.pgm": "pgm", "x.jpg": "", "png": "",
+  } {
+    if got := FormatOf(path); got != want {
+      t.Errorf("FormatOf(%q) = %q, want %q", path, got, want)
+    }
+  }
+}
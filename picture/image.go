@@ -0,0 +1,86 @@
+package picture
+
+import (
+  "image"
+  "image/color"
+)
+
+// Generator returns the value of the pixel at (x, y).
+type Generator func(x, y int) uint8
+
+// FromPic returns a Generator that reads values out of the rows returned
+// by a Pic function.
+func FromPic(data [][]uint8) Generator {
+  return func(x, y int) uint8 {
+    return data[y][x]
+  }
+}
+
+// Palette turns generated values into colors.
+type Palette struct {
+  Model color.Model
+  Color func(v uint8) color.Color
+}
+
+var (
+  // GrayPalette draws each value as that shade of gray.
+  GrayPalette = Palette{
+    Model: color.GrayModel,
+    Color: func(v uint8) color.Color { return color.Gray{Y: v} },
+  }
+
+  // BluePalette uses the value for the red and green channels of a fully
+  // blue pixel, the way the tour's pic.Show draws pictures.
+  BluePalette = Palette{
+    Model: color.RGBAModel,
+    Color: func(v uint8) color.Color { return color.RGBA{R: v, G: v, B: 255, A: 255} },
+  }
+
+  // HeatPalette runs from black through red and yellow to white.
+  HeatPalette = Palette{
+    Model: color.RGBAModel,
+    Color: func(v uint8) color.Color {
+      // Spread v over three ramps of 85 steps, one per channel.
+      n := int(v) * 3
+      r := min(n, 255)
+      g := min(max(n-255, 0), 255)
+      b := max(n-510, 0)
+      return color.RGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 255}
+    },
+  }
+)
+
+// Image is an image.Image whose pixels are computed on demand by Gen and
+// colored by Palette, so it can be passed to any encoder or draw
+// operation in the standard library. A zero Palette draws with
+// GrayPalette.
+type Image struct {
+  W, H    int
+  Gen     Generator
+  Palette Palette
+}
+
+func (m Image) ColorModel() color.Model {
+  return m.palette().Model
+}
+
+func (m Image) Bounds() image.Rectangle {
+  return image.Rect(0, 0, m.W, m.H)
+}
+
+// At returns the color of the pixel at (x, y). Like the standard library's
+// images it returns the zero color for points outside the bounds.
+func (m Image) At(x, y int) color.Color {
+  p := m.palette()
+  if !(image.Point{x, y}.In(m.Bounds())) {
+    return p.Model.Convert(color.Transparent)
+  }
+  return p.Color(m.Gen(x, y))
+}
+
+func (m Image) palette() Palette {
+  if m.Palette.Color == nil {
+    return GrayPalette
+  }
+  return m.Palette
+}
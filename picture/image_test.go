@@ -0,0 +1,96 @@
+package picture
+
+import (
+  "bytes"
+  "image"
+  "image/color"
+  "image/draw"
+  "image/png"
+  "testing"
+)
+
+func xor(x, y int) uint8 { return uint8(x ^ y) }
+
+func TestImage(t *testing.T) {
+  m := Image{W: 40, H: 30, Gen: xor, Palette: GrayPalette}
+
+  if m.Bounds() != image.Rect(0, 0, 40, 30) {
+    t.Errorf("Bounds = %v", m.Bounds())
+  }
+  if m.ColorModel() != color.GrayModel {
+    t.Errorf("ColorModel is not color.GrayModel")
+  }
+  if got := m.At(5, 3); got != (color.Gray{Y: 5 ^ 3}) {
+    t.Errorf("At(5, 3) = %v, want %v", got, color.Gray{Y: 5 ^ 3})
+  }
+  if got := m.At(40, 0); got != (color.Gray{}) {
+    t.Errorf("At outside bounds = %v, want zero", got)
+  }
+}
+
+func TestImagePalettes(t *testing.T) {
+  tests := []struct {
+    p    Palette
+    v    uint8
+    want color.Color
+  }{
+    {GrayPalette, 7, color.Gray{Y: 7}},
+    {BluePalette, 7, color.RGBA{7, 7, 255, 255}},
+    {HeatPalette, 0, color.RGBA{0, 0, 0, 255}},
+    {HeatPalette, 85, color.RGBA{255, 0, 0, 255}},
+    {HeatPalette, 170, color.RGBA{255, 255, 0, 255}},
+    {HeatPalette, 255, color.RGBA{255, 255, 255, 255}},
+  }
+
+  for _, tt := range tests {
+    m := Image{W: 1, H: 1, Gen: func(x, y int) uint8 { return tt.v }, Palette: tt.p}
+    if got := m.At(0, 0); got != tt.want {
+      t.Errorf("At = %v, want %v", got, tt.want)
+    }
+    if got := m.ColorModel().Convert(m.At(0, 0)); got != tt.want {
+      t.Errorf("ColorModel changed %v to %v", tt.want, got)
+    }
+  }
+}
+
+// TestImageStdlib checks Image works with encoders and draw operations.
+func TestImageStdlib(t *testing.T) {
+  m := Image{W: 64, H: 64, Gen: FromPic(Pic(64, 64)), Palette: BluePalette}
+
+  var buf bytes.Buffer
+  if err := png.Encode(&buf, m); err != nil {
+    t.Fatal(err)
+  }
+  decoded, err := png.Decode(&buf)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  dst := image.NewRGBA(m.Bounds())
+  draw.Draw(dst, dst.Bounds(), m, image.Point{}, draw.Src)
+
+  for y := 0; y < 64; y++ {
+    for x := 0; x < 64; x++ {
+      want := m.At(x, y)
+      if got := color.RGBAModel.Convert(decoded.At(x, y)); got != want {
+        t.Fatalf("decoded PNG pixel (%d, %d) = %v, want %v", x, y, got, want)
+      }
+      if got := dst.At(x, y); got != want {
+        t.Fatalf("drawn pixel (%d, %d) = %v, want %v", x, y, got, want)
+      }
+    }
+  }
+}
+
+func TestImageZeroPalette(t *testing.T) {
+  m := Image{W: 2, H: 1, Gen: func(x, y int) uint8 { return 200 }}
+  if got := m.ColorModel(); got != color.GrayModel {
+    t.Errorf("ColorModel() = %v, want color.GrayModel", got)
+  }
+  if got, want := m.At(0, 0), (color.Gray{Y: 200}); got != want {
+    t.Errorf("At(0, 0) = %v, want %v", got, want)
+  }
+  if got, want := m.At(5, 5), (color.Gray{}); got != want {
+    t.Errorf("At(5, 5) = %v, want %v", got, want)
+  }
+}
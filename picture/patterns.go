@@ -0,0 +1,92 @@
+package picture
+
+import (
+  "sort"
+)
+
+// Pattern returns the Generator for a dx by dy picture. Patterns that
+// depend on the size of the picture, like Mandelbrot, scale to fit it.
+type Pattern func(dx, dy int) Generator
+
+// Pic renders p as the rows of a dx by dy picture, like the Pic exercise.
+func (p Pattern) Pic(dx, dy int) [][]uint8 {
+  return Render(p(dx, dy), dx, dy)
+}
+
+// Render evaluates gen at every pixel of a dx by dy picture and returns
+// the rows, top to bottom.
+func Render(gen Generator, dx, dy int) [][]uint8 {
+  pic := make([][]uint8, dy)
+  for y := range pic {
+    pic[y] = make([]uint8, dx)
+    for x := range pic[y] {
+      pic[y][x] = gen(x, y)
+    }
+  }
+  return pic
+}
+
+// fixed adapts a Generator that ignores the picture size to a Pattern.
+func fixed(gen Generator) Pattern {
+  return func(dx, dy int) Generator { return gen }
+}
+
+var patterns = map[string]Pattern{
+  "shift":        fixed(func(x, y int) uint8 { return uint8(x) << (uint8(y) / 32) }),
+  "average":      fixed(func(x, y int) uint8 { return uint8((x + y) / 2) }),
+  "product":      fixed(func(x, y int) uint8 { return uint8(x * y) }),
+  "xor":          fixed(func(x, y int) uint8 { return uint8(x ^ y) }),
+  "powmod":       fixed(powmod),
+  "checkerboard": checkerboard,
+  "mandelbrot":   mandelbrot,
+  "perlin":       perlin,
+}
+
+// Register makes a pattern available by name. It panics if the name is
+// already taken.
+func Register(name string, p Pattern) {
+  if _, present := patterns[name]; present {
+    panic("picture: duplicate pattern " + name)
+  }
+  patterns[name] = p
+}
+
+// Lookup returns the pattern registered as name.
+func Lookup(name string) (Pattern, bool) {
+  p, ok := patterns[name]
+  return p, ok
+}
+
+// Patterns returns the names of every registered pattern, sorted.
+func Patterns() []string {
+  names := make([]string, 0, len(patterns))
+  for name := range patterns {
+    names = append(names, name)
+  }
+  sort.Strings(names)
+  return names
+}
+
+// powmod is x raised to the power y, modulo 256, computed by repeated
+// squaring in uint8 arithmetic, which wraps at 256 for free.
+func powmod(x, y int) uint8 {
+  base, result := uint8(x), uint8(1)
+  for e := uint(y); e > 0; e >>= 1 {
+    if e&1 == 1 {
+      result *= base
+    }
+    base *= base
+  }
+  return result
+}
+
+// checkerboard draws eight squares across the wider side of the picture.
+func checkerboard(dx, dy int) Generator {
+  size := max(max(dx, dy)/8, 1)
+  return func(x, y int) uint8 {
+    if (x/size+y/size)%2 == 0 {
+      return 255
+    }
+    return 0
+  }
+}
@@ -0,0 +1,109 @@
+package picture
+
+import (
+  "crypto/sha256"
+  "fmt"
+  "reflect"
+  "testing"
+)
+
+// hashPic returns a short hash of the rows of a picture.
+func hashPic(pic [][]uint8) string {
+  h := sha256.New()
+  for _, row := range pic {
+    h.Write(row)
+  }
+  return fmt.Sprintf("%x", h.Sum(nil)[:8])
+}
+
+// TestPatternHashes pins the output of every built-in pattern, at a square
+// and an odd-sized resolution, so that changes to a pattern are noticed.
+func TestPatternHashes(t *testing.T) {
+  want := map[string][2]string{
+    "average":      {"2d9560dfe43979a9", "259d1fd5beafc112"},
+    "checkerboard": {"60ebf37a61a79acd", "46a62bb53eb318a2"},
+    "mandelbrot":   {"a6e44d49b2423672", "0f9e101844f3586c"},
+    "perlin":       {"f0cf1766dccf25f5", "4c6ff49fc4396420"},
+    "powmod":       {"bcb9924da16736e8", "2b3974cc8805e462"},
+    "product":      {"4eb7f260f91b5491", "f566d8e38f44bf1d"},
+    "shift":        {"35fdd81214d76573", "b72e8a3f8f101be0"},
+    "xor":          {"f0a3a4299328c597", "00c171dc0cfd79fa"},
+  }
+
+  for _, name := range Patterns() {
+    p, _ := Lookup(name)
+    got := [2]string{hashPic(p.Pic(256, 256)), hashPic(p.Pic(97, 61))}
+
+    w, ok := want[name]
+    if !ok {
+      t.Errorf("pattern %q has no expected hashes", name)
+      continue
+    }
+    if got != w {
+      t.Errorf("pattern %q hashes = %q, want %q", name, got, w)
+    }
+
+    // Rendering again must give the same picture.
+    if again := hashPic(p.Pic(256, 256)); again != got[0] {
+      t.Errorf("pattern %q is not deterministic", name)
+    }
+  }
+}
+
+func TestPatternSizes(t *testing.T) {
+  for _, name := range Patterns() {
+    p, _ := Lookup(name)
+    for _, size := range [][2]int{{1, 1}, {3, 200}, {200, 3}, {0, 0}} {
+      pic := p.Pic(size[0], size[1])
+      if len(pic) != size[1] {
+        t.Fatalf("%s: %dx%d picture has %d rows", name, size[0], size[1], len(pic))
+      }
+      for _, row := range pic {
+        if len(row) != size[0] {
+          t.Fatalf("%s: %dx%d picture has a row of %d", name, size[0], size[1], len(row))
+        }
+      }
+    }
+  }
+}
+
+func TestShiftMatchesPic(t *testing.T) {
+  shift, _ := Lookup("shift")
+  if !reflect.DeepEqual(shift.Pic(300, 200), Pic(300, 200)) {
+    t.Error(`"shift" pattern differs from Pic`)
+  }
+}
+
+func TestPowmod(t *testing.T) {
+  for _, tt := range [][3]int{{0, 0, 1}, {2, 7, 128}, {2, 8, 0}, {3, 5, 243}, {3, 6, 729 % 256}, {255, 2, 1}} {
+    if got := powmod(tt[0], tt[1]); int(got) != tt[2] {
+      t.Errorf("powmod(%d, %d) = %d, want %d", tt[0], tt[1], got, tt[2])
+    }
+  }
+}
+
+func TestPerm(t *testing.T) {
+  seen := make(map[int]bool)
+  for _, v := range perm[:256] {
+    seen[v] = true
+  }
+  if len(seen) != 256 {
+    t.Errorf("perm has %d distinct values, want 256", len(seen))
+  }
+}
+
+func TestRegister(t *testing.T) {
+  Register("test-diagonal", fixed(func(x, y int) uint8 { return uint8(x - y) }))
+  defer delete(patterns, "test-diagonal")
+
+  if _, ok := Lookup("test-diagonal"); !ok {
+    t.Fatal("registered pattern not found")
+  }
+
+  defer func() {
+    if recover() == nil {
+      t.Error("registering a duplicate pattern did not panic")
+    }
+  }()
+  Register("xor", fixed(nil))
+}
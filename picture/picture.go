@@ -1,7 +1,7 @@
-package main
-
-import "golang.org/x/tour/pic"
+package picture
 
+// Pic returns a dy by dx slice of slices whose values are used as the
+// grayscale intensity of each pixel when the picture is displayed.
 func Pic(dx, dy int) [][]uint8 {
     pic := make([][]uint8, dy)
 
@@ -13,7 +13,3 @@ func Pic(dx, dy int) [][]uint8 {
     }
     return pic
 }
-
-func main() {
-    pic.Show(Pic)
-}
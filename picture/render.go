@@ -0,0 +1,74 @@
+package picture
+
+import (
+  "image"
+  "runtime"
+  "sync"
+)
+
+// DefaultTileSize is the side, in pixels, of the square tiles a Renderer
+// splits a picture into when its TileSize is zero.
+const DefaultTileSize = 64
+
+// Renderer renders a Generator in tiles on a pool of goroutines. The
+// result is identical to Render's, but the Generator must be safe to call
+// from several goroutines at once, as all the built-in patterns are.
+// The zero value is ready to use.
+type Renderer struct {
+  // TileSize is the side of each square tile in pixels. Zero means
+  // DefaultTileSize.
+  TileSize int
+
+  // Workers is the number of goroutines rendering tiles. Zero means
+  // runtime.GOMAXPROCS(0), one per CPU the scheduler will use.
+  Workers int
+}
+
+// Render evaluates gen at every pixel of a dx by dy picture and returns
+// the rows, top to bottom.
+func (r Renderer) Render(gen Generator, dx, dy int) [][]uint8 {
+  size := r.TileSize
+  if size <= 0 {
+    size = DefaultTileSize
+  }
+
+  workers := r.Workers
+  if workers <= 0 {
+    workers = runtime.GOMAXPROCS(0)
+  }
+
+  // One allocation for the whole picture, sliced into rows, so tiles are
+  // written straight into place with no merging afterwards.
+  pix := make([]uint8, dx*dy)
+  pic := make([][]uint8, dy)
+  for y := range pic {
+    pic[y] = pix[y*dx : (y+1)*dx : (y+1)*dx]
+  }
+
+  tiles := make(chan image.Rectangle)
+  var wg sync.WaitGroup
+  for w := 0; w < workers; w++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+      for t := range tiles {
+        for y := t.Min.Y; y < t.Max.Y; y++ {
+          row := pic[y]
+          for x := t.Min.X; x < t.Max.X; x++ {
+            row[x] = gen(x, y)
+          }
+        }
+      }
+    }()
+  }
+
+  for y := 0; y < dy; y += size {
+    for x := 0; x < dx; x += size {
+      tiles <- image.Rect(x, y, min(x+size, dx), min(y+size, dy))
+    }
+  }
+  close(tiles)
+  wg.Wait()
+
+  return pic
+}
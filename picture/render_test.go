@@ -0,0 +1,61 @@
+package picture
+
+import (
+  "fmt"
+  "reflect"
+  "testing"
+)
+
+func TestRendererMatchesRender(t *testing.T) {
+  renderers := []Renderer{
+    {},
+    {TileSize: 1, Workers: 3},
+    {TileSize: 7, Workers: 2},
+    {TileSize: 1000, Workers: 8},
+  }
+
+  for _, name := range Patterns() {
+    p, _ := Lookup(name)
+    for _, size := range [][2]int{{0, 0}, {1, 1}, {131, 67}, {64, 128}} {
+      dx, dy := size[0], size[1]
+      want := Render(p(dx, dy), dx, dy)
+
+      for _, r := range renderers {
+        if got := r.Render(p(dx, dy), dx, dy); !reflect.DeepEqual(got, want) {
+          t.Errorf("%+v rendering %s at %dx%d differs from Render", r, name, dx, dy)
+        }
+      }
+    }
+  }
+}
+
+func BenchmarkRender(b *testing.B) {
+  sizes := []struct {
+    name   string
+    dx, dy int
+  }{
+    {"4K", 3840, 2160},
+    {"8K", 7680, 4320},
+  }
+
+  for _, pattern := range []string{"xor", "mandelbrot"} {
+    p, _ := Lookup(pattern)
+    for _, s := range sizes {
+      gen := p(s.dx, s.dy)
+
+      b.Run(fmt.Sprintf("%s/%s/sequential", pattern, s.name), func(b *testing.B) {
+        b.SetBytes(int64(s.dx * s.dy))
+        for i := 0; i < b.N; i++ {
+          Render(gen, s.dx, s.dy)
+        }
+      })
+
+      b.Run(fmt.Sprintf("%s/%s/tiled", pattern, s.name), func(b *testing.B) {
+        b.SetBytes(int64(s.dx * s.dy))
+        for i := 0; i < b.N; i++ {
+          Renderer{}.Render(gen, s.dx, s.dy)
+        }
+      })
+    }
+  }
+}
@@ -0,0 +1,136 @@
+package picture
+
+import (
+  "bufio"
+  "fmt"
+  "image/color"
+  "io"
+)
+
+// ASCIIRamp lists characters from darkest to lightest, as they appear on
+// a terminal with a dark background.
+const ASCIIRamp = " .:-=+*#%@"
+
+// WriteASCII draws data on w as text, at most cols characters wide and
+// rows lines tall, using one character of ASCIIRamp per cell. Terminal
+// cells are about twice as tall as they are wide, so each cell covers twice
+// as many rows as columns to keep the picture's proportions.
+func WriteASCII(w io.Writer, data [][]uint8, cols, rows int) error {
+  dx, dy, err := size(data)
+  if err != nil {
+    return err
+  }
+  ox, oy := fit(dx, dy, cols, rows, 1)
+  if ox == 0 {
+    return nil
+  }
+
+  bw := bufio.NewWriter(w)
+  for _, row := range downscale(data, ox, oy) {
+    for _, v := range row {
+      bw.WriteByte(ASCIIRamp[int(v)*len(ASCIIRamp)/256])
+    }
+    bw.WriteByte('\n')
+  }
+  return bw.Flush()
+}
+
+// WriteANSI draws data on w with 24-bit color ANSI escapes, at most cols
+// characters wide and rows lines tall. Each character is an upper half
+// block "▀" whose foreground is one pixel and whose background is the pixel
+// below it, so pixels come out square. Colors come from p.
+func WriteANSI(w io.Writer, data [][]uint8, p Palette, cols, rows int) error {
+  dx, dy, err := size(data)
+  if err != nil {
+    return err
+  }
+  ox, oy := fit(dx, dy, cols, rows, 2)
+  if ox == 0 {
+    return nil
+  }
+
+  small := downscale(data, ox, oy)
+  bw := bufio.NewWriter(w)
+  for y := 0; y < oy; y += 2 {
+    for x := 0; x < ox; x++ {
+      r, g, b := rgb(p.Color(small[y][x]))
+      fmt.Fprintf(bw, "\x1b[38;2;%d;%d;%dm", r, g, b)
+
+      // An odd last row has nothing below it, so leave the default
+      // background showing.
+      if y+1 < oy {
+        r, g, b := rgb(p.Color(small[y+1][x]))
+        fmt.Fprintf(bw, "\x1b[48;2;%d;%d;%dm", r, g, b)
+      }
+      bw.WriteString("▀")
+    }
+    bw.WriteString("\x1b[0m\n")
+  }
+  return bw.Flush()
+}
+
+// fit returns the size to shrink a dx by dy picture to, keeping its
+// proportions, so it fits in cols columns and rows lines of text where each
+// line shows perLine rows of pixels. A cell is about twice as tall as it is
+// wide, so with perLine 1 each output row stands for two columns' worth of
+// the picture. It returns 0, 0 if nothing fits.
+func fit(dx, dy, cols, rows, perLine int) (ox, oy int) {
+  ox = min(cols, dx)
+  if ox <= 0 || rows <= 0 || dy == 0 {
+    return 0, 0
+  }
+  oy = max(dy*ox*perLine/dx/2, 1)
+  if oy > rows*perLine {
+    oy = rows * perLine
+    ox = max(dx*oy*2/perLine/dy, 1)
+  }
+  return ox, oy
+}
+
+// size returns the width and height of data, checking, as Gray does, that
+// every row is the same length.
+func size(data [][]uint8) (dx, dy int, err error) {
+  dy = len(data)
+  if dy > 0 {
+    dx = len(data[0])
+  }
+  for y, row := range data {
+    if len(row) != dx {
+      return 0, 0, fmt.Errorf("picture: row %d has %d values, want %d", y, len(row), dx)
+    }
+  }
+  return dx, dy, nil
+}
+
+// downscale shrinks data to ox by oy by averaging the block of pixels
+// under each output pixel. Neither ox nor oy may exceed data's size, and
+// data's rows must all be the same length.
+func downscale(data [][]uint8, ox, oy int) [][]uint8 {
+  dy := len(data)
+  dx := len(data[0])
+
+  out := make([][]uint8, oy)
+  for y := range out {
+    out[y] = make([]uint8, ox)
+    y0, y1 := y*dy/oy, (y+1)*dy/oy
+
+    for x := range out[y] {
+      x0, x1 := x*dx/ox, (x+1)*dx/ox
+
+      sum := 0
+      for sy := y0; sy < y1; sy++ {
+        for sx := x0; sx < x1; sx++ {
+          sum += int(data[sy][sx])
+        }
+      }
+      n := (y1 - y0) * (x1 - x0)
+      out[y][x] = uint8((sum + n/2) / n)
+    }
+  }
+  return out
+}
+
+func rgb(c color.Color) (r, g, b uint8) {
+  rr, gg, bb, _ := c.RGBA()
+  return uint8(rr >> 8), uint8(gg >> 8), uint8(bb >> 8)
+}
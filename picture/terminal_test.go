@@ -0,0 +1,108 @@
+package picture
+
+import (
+  "reflect"
+  "strings"
+  "testing"
+)
+
+func TestDownscale(t *testing.T) {
+  data := [][]uint8{
+    {0, 10, 100, 100},
+    {20, 30, 100, 100},
+  }
+  want := [][]uint8{{15, 100}}
+
+  if got := downscale(data, 2, 1); !reflect.DeepEqual(got, want) {
+    t.Errorf("downscale = %v, want %v", got, want)
+  }
+  if got := downscale(data, 4, 2); !reflect.DeepEqual(got, data) {
+    t.Errorf("downscale to the same size = %v, want %v", got, data)
+  }
+}
+
+func TestWriteASCII(t *testing.T) {
+  data := [][]uint8{
+    {0, 64, 128, 255},
+    {0, 64, 128, 255},
+  }
+
+  var b strings.Builder
+  if err := WriteASCII(&b, data, 80, 24); err != nil {
+    t.Fatal(err)
+  }
+  if got, want := b.String(), " :+@\n"; got != want {
+    t.Errorf("WriteASCII = %q, want %q", got, want)
+  }
+
+  // A wide picture is shrunk to fit and keeps its aspect ratio.
+  b.Reset()
+  WriteASCII(&b, Pic(256, 256), 32, 24)
+  lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+  if len(lines) != 16 || len(lines[0]) != 32 {
+    t.Errorf("256x256 picture at 32 columns is %dx%d characters, want 32x16", len(lines[0]), len(lines))
+  }
+
+  // A tall picture is shrunk to fit the height as well.
+  b.Reset()
+  WriteASCII(&b, Pic(100, 1000), 80, 25)
+  lines = strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+  if len(lines) != 25 || len(lines[0]) != 5 {
+    t.Errorf("100x1000 picture in 80x25 is %dx%d characters, want 5x25", len(lines[0]), len(lines))
+  }
+}
+
+func TestWriteANSI(t *testing.T) {
+  data := [][]uint8{
+    {0, 255},
+    {255, 0},
+    {7, 7},
+  }
+
+  var b strings.Builder
+  if err := WriteANSI(&b, data, GrayPalette, 80, 24); err != nil {
+    t.Fatal(err)
+  }
+
+  want := "" +
+    "\x1b[38;2;0;0;0m\x1b[48;2;255;255;255m▀" +
+    "\x1b[38;2;255;255;255m\x1b[48;2;0;0;0m▀" +
+    "\x1b[0m\n" +
+    "\x1b[38;2;7;7;7m▀" +
+    "\x1b[38;2;7;7;7m▀" +
+    "\x1b[0m\n"
+  if b.String() != want {
+    t.Errorf("WriteANSI = %q, want %q", b.String(), want)
+  }
+
+  // A tall picture is shrunk to fit the height as well.
+  b.Reset()
+  WriteANSI(&b, Pic(100, 1000), GrayPalette, 80, 25)
+  lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+  if n := strings.Count(lines[0], "▀"); len(lines) != 25 || n != 5 {
+    t.Errorf("100x1000 picture in 80x25 is %dx%d characters, want 5x25", n, len(lines))
+  }
+}
+
+func TestWriteEmpty(t *testing.T) {
+  var b strings.Builder
+  if WriteASCII(&b, nil, 80, 24) != nil || WriteANSI(&b, nil, GrayPalette, 80, 24) != nil || b.Len() != 0 {
+    t.Errorf("writing an empty picture produced %q", b.String())
+  }
+}
+
+func TestWriteRagged(t *testing.T) {
+  data := [][]uint8{{1, 2, 3}, {1}}
+  want := "picture: row 1 has 1 values, want 3"
+
+  var b strings.Builder
+  if err := WriteASCII(&b, data, 80, 24); err == nil || err.Error() != want {
+    t.Errorf("WriteASCII of ragged rows: error = %v, want %q", err, want)
+  }
+  if err := WriteANSI(&b, data, GrayPalette, 80, 24); err == nil || err.Error() != want {
+    t.Errorf("WriteANSI of ragged rows: error = %v, want %q", err, want)
+  }
+  if b.Len() != 0 {
+    t.Errorf("ragged rows still wrote %q", b.String())
+  }
+}
@@ -1,17 +0,0 @@
-package main
-
-import "golang.org/x/tour/reader"
-
-type MyReader struct{}
-
-func (r MyReader) Read(bytes []byte) (int, error) {
-  for i := range bytes {
-    bytes[i] = 65
-  }
-
-  return len(bytes), nil
-}
-
-func main() {
-	reader.Validate(MyReader{})
-}
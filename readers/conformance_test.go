@@ -0,0 +1,39 @@
+package readers
+
+import (
+  "io"
+  "strings"
+  "testing"
+
+  "github.com/lollar/gotour/readers/readertest"
+)
+
+func TestConformance(t *testing.T) {
+  const text = "Lbh penpxrq gur pbqr! Ωμέγα ǅ \xff"
+
+  finite := map[string]func() io.Reader{
+    "Rot13Reader":          func() io.Reader { return Rot13Reader{R: strings.NewReader(text)} },
+    "TransformReader":      func() io.Reader { return NewTransformReader(strings.NewReader(text), Caesar(5)) },
+    "TransformReader/rune": func() io.Reader { return NewTransformReader(strings.NewReader(text), SwapCase) },
+    "Random/Limit":         func() io.Reader { return Random(9).Limit(100000) },
+    "Counting/Limit":       func() io.Reader { return Counting().Limit(777) },
+    "Zeros/Limit(0)":       func() io.Reader { return Zeros().Limit(0) },
+  }
+  for name, newReader := range finite {
+    t.Run(name, func(t *testing.T) {
+      readertest.Test(t, newReader, nil)
+    })
+  }
+
+  endless := map[string]func() io.Reader{
+    "MyReader": func() io.Reader { return MyReader{} },
+    "Repeat":   func() io.Reader { return Repeat([]byte("abc")) },
+  }
+  for name, newReader := range endless {
+    t.Run(name, func(t *testing.T) {
+      if rep := (readertest.Checker{MaxBytes: 1 << 16}).Check(newReader, nil); !rep.OK() {
+        t.Error(rep)
+      }
+    })
+  }
+}
@@ -0,0 +1,108 @@
+package readers
+
+import (
+  "encoding/binary"
+  "io"
+)
+
+// Generator is an io.Reader that synthesizes a byte stream, endless
+// unless bounded with Limit. Every byte is a function of its offset in the
+// stream, so the output does not depend on how it is read.
+type Generator struct {
+  fill  func(p []byte, off int64)
+  off   int64
+  limit int64 // or -1 for no limit
+}
+
+func newGenerator(fill func(p []byte, off int64)) *Generator {
+  return &Generator{fill: fill, limit: -1}
+}
+
+// Zeros returns a generator of zero bytes.
+func Zeros() *Generator {
+  return newGenerator(func(p []byte, off int64) {
+    clear(p)
+  })
+}
+
+// Repeat returns a generator that emits pattern over and over;
+// Repeat([]byte("A")) behaves like MyReader. It panics if pattern is
+// empty.
+func Repeat(pattern []byte) *Generator {
+  if len(pattern) == 0 {
+    panic("readers: Repeat of an empty pattern")
+  }
+  pattern = append([]byte(nil), pattern...)
+
+  return newGenerator(func(p []byte, off int64) {
+    n := copy(p, pattern[off%int64(len(pattern)):])
+    for n < len(p) {
+      n += copy(p[n:], pattern)
+    }
+  })
+}
+
+// Counting returns a generator whose bytes count up from 0 to 255 and
+// wrap around, so each byte is its offset modulo 256.
+func Counting() *Generator {
+  return newGenerator(func(p []byte, off int64) {
+    for i := range p {
+      p[i] = byte(off + int64(i))
+    }
+  })
+}
+
+// Random returns a generator of pseudo-random bytes. The same seed always
+// yields the same stream. The bytes are not suitable for cryptography.
+func Random(seed uint64) *Generator {
+  // Each 8-byte block is a hash of the block's index and a key mixed from
+  // the seed. Adding the seed to the index directly would make Random(s+1)
+  // the stream of Random(s) shifted by one block.
+  key := splitmix64(seed)
+  return newGenerator(func(p []byte, off int64) {
+    var block [8]byte
+    for len(p) > 0 {
+      if off%8 == 0 && len(p) >= 8 {
+        binary.LittleEndian.PutUint64(p, splitmix64(key^uint64(off/8)))
+        p, off = p[8:], off+8
+        continue
+      }
+      binary.LittleEndian.PutUint64(block[:], splitmix64(key^uint64(off/8)))
+      n := copy(p, block[off%8:])
+      p, off = p[n:], off+int64(n)
+    }
+  })
+}
+
+// splitmix64 is the output function of the SplitMix64 generator, which
+// maps consecutive inputs to well mixed outputs.
+func splitmix64(x uint64) uint64 {
+  x += 0x9e3779b97f4a7c15
+  x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+  x = (x ^ x>>27) * 0x94d049bb133111eb
+  return x ^ x>>31
+}
+
+// Limit makes g stop with io.EOF once it has produced n bytes in total,
+// and returns g. A negative n removes the limit.
+func (g *Generator) Limit(n int64) *Generator {
+  g.limit = n
+  return g
+}
+
+// Read fills p with the next bytes of the stream. A bounded generator
+// returns io.EOF once its limit is reached.
+func (g *Generator) Read(p []byte) (int, error) {
+  if g.limit >= 0 {
+    if g.off >= g.limit {
+      return 0, io.EOF
+    }
+    if rem := g.limit - g.off; int64(len(p)) > rem {
+      p = p[:rem]
+    }
+  }
+
+  g.fill(p, g.off)
+  g.off += int64(len(p))
+  return len(p), nil
+}
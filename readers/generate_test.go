@@ -0,0 +1,155 @@
+package readers
+
+import (
+  "bytes"
+  "encoding/binary"
+  "fmt"
+  "io"
+  "testing"
+  "testing/iotest"
+)
+
+func TestGenerators(t *testing.T) {
+  tests := []struct {
+    name string
+    gen  func() *Generator
+    want []byte
+  }{
+    {"zeros", Zeros, make([]byte, 10)},
+    {"repeat", func() *Generator { return Repeat([]byte("abc")) }, []byte("abcabcabca")},
+    {"counting", Counting, []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+  }
+  for _, tt := range tests {
+    got, err := io.ReadAll(tt.gen().Limit(int64(len(tt.want))))
+    if err != nil || !bytes.Equal(got, tt.want) {
+      t.Errorf("%s: read %q, %v, want %q", tt.name, got, err, tt.want)
+    }
+  }
+
+  got, _ := io.ReadAll(Counting().Limit(300))
+  if got[255] != 255 || got[256] != 0 || got[299] != 43 {
+    t.Errorf("Counting does not wrap at 256: got[255:258] = %v", got[255:258])
+  }
+}
+
+func TestGeneratorReadSizes(t *testing.T) {
+  // However the stream is read, it must come out the same.
+  gens := map[string]func() *Generator{
+    "zeros":    Zeros,
+    "repeat":   func() *Generator { return Repeat([]byte("0123456789abcdefXYZ")) },
+    "counting": Counting,
+    "random":   func() *Generator { return Random(42) },
+  }
+  for name, gen := range gens {
+    want, _ := io.ReadAll(gen().Limit(1000))
+    if len(want) != 1000 {
+      t.Fatalf("%s: Limit(1000) gave %d bytes", name, len(want))
+    }
+    if err := iotest.TestReader(gen().Limit(1000), want); err != nil {
+      t.Errorf("%s: %v", name, err)
+    }
+    got, _ := io.ReadAll(iotest.OneByteReader(gen().Limit(1000)))
+    if !bytes.Equal(got, want) {
+      t.Errorf("%s: one-byte reads differ from bulk reads", name)
+    }
+  }
+}
+
+func TestRandom(t *testing.T) {
+  a, _ := io.ReadAll(Random(1).Limit(64))
+  b, _ := io.ReadAll(Random(1).Limit(64))
+  c, _ := io.ReadAll(Random(2).Limit(64))
+  if !bytes.Equal(a, b) {
+    t.Errorf("Random(1) is not deterministic")
+  }
+  if bytes.Equal(a, c) {
+    t.Errorf("Random(1) and Random(2) produced the same bytes")
+  }
+
+  // Every byte value should turn up about equally often.
+  var counts [256]int
+  data, _ := io.ReadAll(Random(7).Limit(1 << 18))
+  for _, v := range data {
+    counts[v]++
+  }
+  for v, n := range counts {
+    if n < 850 || n > 1200 {
+      t.Errorf("byte %d appeared %d times in 2^18, want about 1024", v, n)
+    }
+  }
+}
+
+func TestRandomSeedsIndependent(t *testing.T) {
+  // Neighbouring seeds must not give the same stream at some offset: no
+  // 8-byte window of one may appear anywhere in the other.
+  windows := func(data []byte) map[uint64]bool {
+    m := make(map[uint64]bool)
+    for i := 0; i+8 <= len(data); i++ {
+      m[binary.LittleEndian.Uint64(data[i:])] = true
+    }
+    return m
+  }
+
+  for seed := uint64(0); seed < 4; seed++ {
+    a, _ := io.ReadAll(Random(seed).Limit(4096))
+    b, _ := io.ReadAll(Random(seed + 1).Limit(4096))
+    seen := windows(a)
+    for i := 0; i+8 <= len(b); i++ {
+      if seen[binary.LittleEndian.Uint64(b[i:])] {
+        t.Errorf("Random(%d) bytes %d-%d also appear in Random(%d)", seed+1, i, i+8, seed)
+        break
+      }
+    }
+  }
+}
+
+func TestLimit(t *testing.T) {
+  g := Zeros().Limit(0)
+  if n, err := g.Read(make([]byte, 8)); n != 0 || err != io.EOF {
+    t.Errorf("Read after Limit(0) = %d, %v, want 0, EOF", n, err)
+  }
+
+  // Removing the limit lets reading carry on.
+  g.Limit(-1)
+  if n, err := g.Read(make([]byte, 8)); n != 8 || err != nil {
+    t.Errorf("Read after Limit(-1) = %d, %v, want 8, nil", n, err)
+  }
+
+  n, err := io.Copy(io.Discard, Random(3).Limit(12345))
+  if n != 12345 || err != nil {
+    t.Errorf("copied %d, %v, want exactly 12345 bytes", n, err)
+  }
+}
+
+func TestRepeatLikeMyReader(t *testing.T) {
+  want := make([]byte, 100)
+  MyReader{}.Read(want)
+  got := make([]byte, 100)
+  Repeat([]byte("A")).Read(got)
+  if !bytes.Equal(got, want) {
+    t.Errorf("Repeat(A) = %q, want %q", got, want)
+  }
+}
+
+func BenchmarkGenerators(b *testing.B) {
+  gens := []struct {
+    name string
+    gen  *Generator
+  }{
+    {"zeros", Zeros()},
+    {"repeat", Repeat([]byte("the quick brown fox jumps over the lazy dog "))},
+    {"counting", Counting()},
+    {"random", Random(1)},
+  }
+  for _, g := range gens {
+    for _, size := range []int{512, 32 << 10} {
+      b.Run(fmt.Sprintf("%s/%d", g.name, size), func(b *testing.B) {
+        p := make([]byte, size)
+        b.SetBytes(int64(size))
+        for range b.N {
+          g.gen.Read(p)
+        }
+      })
+    }
+  }
+}
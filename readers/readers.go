@@ -0,0 +1,33 @@
+// Package readers holds the solutions to the tour's Readers exercises,
+// along with readers and writers that transform the bytes passing through
+// them.
+package readers
+
+import "io"
+
+// MyReader is an io.Reader that emits an infinite stream of the ASCII
+// character 'A'.
+type MyReader struct{}
+
+func (r MyReader) Read(bytes []byte) (int, error) {
+  for i := range bytes {
+    bytes[i] = 65
+  }
+
+  return len(bytes), nil
+}
+
+// Rot13Reader is the tour's rot13Reader: it wraps an io.Reader, replacing
+// every letter with the one 13 places along the alphabet.
+type Rot13Reader struct {
+  R io.Reader
+}
+
+func (r Rot13Reader) Read(bytes []byte) (int, error) {
+  n, err := r.R.Read(bytes)
+  for i := range bytes[:n] {
+    bytes[i] = Rot13.Byte(bytes[i])
+  }
+
+  return n, err
+}
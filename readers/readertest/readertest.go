@@ -0,0 +1,329 @@
+// Package readertest checks io.Reader implementations against the
+// io.Reader contract. It reads the same stream many ways: in one-byte and
+// huge reads, interleaved with zero-length reads, and through the
+// testing/iotest wrappers. Each call is checked, and every way of reading
+// must produce the same bytes.
+package readertest
+
+import (
+  "bytes"
+  "errors"
+  "fmt"
+  "io"
+  "strings"
+  "testing"
+  "testing/iotest"
+)
+
+// DefaultMaxBytes is how much of each stream Checker reads when MaxBytes
+// is zero.
+const DefaultMaxBytes = 1 << 20
+
+// hugeBuffer is the size of the buffer used by the "huge reads" check.
+const hugeBuffer = 4 << 20
+
+// stallLimit is how many consecutive Read calls may return 0, nil before
+// a check gives up on the reader.
+const stallLimit = 100
+
+// errStalled stops a check, and any iotest wrapper retrying underneath
+// it, once a reader has stalled.
+var errStalled = errors.New("readertest: reader made no progress")
+
+// Violation is one way a reader broke the io.Reader contract.
+type Violation struct {
+  Check   string // the way the stream was being read, e.g. "one-byte reads"
+  Call    int    // which call to Read, counting from 1; 0 if not about one call
+  Len     int    // len(p) in that call
+  N       int    // the count it returned
+  Err     error  // the error it returned
+  Problem string
+}
+
+func (v Violation) String() string {
+  if v.Call == 0 {
+    return fmt.Sprintf("%s: %s", v.Check, v.Problem)
+  }
+  return fmt.Sprintf("%s: call %d: Read(%d-byte buffer) = %d, %v: %s", v.Check, v.Call, v.Len, v.N, v.Err, v.Problem)
+}
+
+// Report lists the violations found by Check.
+type Report struct {
+  Violations []Violation
+}
+
+// OK reports whether no violations were found.
+func (r *Report) OK() bool {
+  return len(r.Violations) == 0
+}
+
+// Err returns r as an error, or nil if r is OK.
+func (r *Report) Err() error {
+  if r.OK() {
+    return nil
+  }
+  return r
+}
+
+func (r *Report) Error() string {
+  lines := make([]string, len(r.Violations))
+  for i, v := range r.Violations {
+    lines[i] = v.String()
+  }
+  return fmt.Sprintf("readertest: %d violations:\n%s", len(lines), strings.Join(lines, "\n"))
+}
+
+func (r *Report) add(v Violation) {
+  r.Violations = append(r.Violations, v)
+}
+
+// Checker runs readers through the checks. The zero value is ready to
+// use.
+type Checker struct {
+  // MaxBytes is how much of each stream to read, so that endless readers
+  // can be checked too. Zero means DefaultMaxBytes.
+  MaxBytes int
+}
+
+// Check is Checker{}.Check.
+func Check(newReader func() io.Reader, want []byte) *Report {
+  return Checker{}.Check(newReader, want)
+}
+
+// Test runs Check and fails t with each violation found.
+func Test(t testing.TB, newReader func() io.Reader, want []byte) {
+  t.Helper()
+  for _, v := range Check(newReader, want).Violations {
+    t.Error(v)
+  }
+}
+
+// Check reads streams from newReader, which must return a fresh reader at
+// the start of the same stream each time it is called. If want is not
+// nil, each way of reading must produce exactly want, or its first
+// MaxBytes bytes; otherwise they must all agree with plain reads.
+func (c Checker) Check(newReader func() io.Reader, want []byte) *Report {
+  limit := c.MaxBytes
+  if limit <= 0 {
+    limit = DefaultMaxBytes
+  }
+  rep := new(Report)
+
+  type check struct {
+    name    string
+    size    int                       // buffer size for each Read
+    wrap    func(io.Reader) io.Reader // iotest wrapper, if any
+    zero    bool                      // interleave zero-length reads
+    timeout bool                      // wrap fails a read on purpose
+  }
+  checks := []check{
+    {name: "plain reads", size: 4096},
+    {name: "one-byte reads", size: 1},
+    {name: "zero-length reads", size: 7, zero: true},
+    {name: "huge reads", size: hugeBuffer},
+    {name: "iotest.OneByteReader", size: 4096, wrap: iotest.OneByteReader},
+    {name: "iotest.HalfReader", size: 4096, wrap: iotest.HalfReader},
+    {name: "iotest.DataErrReader", size: 4096, wrap: iotest.DataErrReader},
+    {name: "iotest.TimeoutReader", size: 4096, wrap: iotest.TimeoutReader, timeout: true},
+  }
+
+  var first []byte
+  complete := false
+  for i, ck := range checks {
+    cr := &checkedReader{r: newReader(), check: ck.name, rep: rep}
+    var r io.Reader = cr
+    if ck.wrap != nil {
+      r = ck.wrap(r)
+    }
+    got, eof := read(r, cr, ck.size, ck.zero, ck.timeout, limit)
+    if eof {
+      cr.probeEOF()
+    }
+
+    if i == 0 {
+      first, complete = got, eof
+      if want == nil {
+        continue
+      }
+    }
+    expect, what := want, "the wanted stream"
+    if want == nil {
+      expect, what = first, "plain reads"
+    }
+    if len(expect) > limit {
+      expect = expect[:limit]
+    }
+    compare(rep, ck.name, got, expect, what)
+  }
+
+  // iotest.TestReader checks a finite stream end to end, including Seek
+  // if the reader has it.
+  if complete {
+    expect := want
+    if expect == nil {
+      expect = first
+    }
+    testReader(newReader(), expect, rep)
+  }
+  return rep
+}
+
+// testReader runs iotest.TestReader on r, guarding against stalls and
+// panics as the other checks do.
+func testReader(r io.Reader, want []byte, rep *Report) {
+  const check = "iotest.TestReader"
+  defer func() {
+    if e := recover(); e != nil {
+      rep.add(Violation{Check: check, Problem: fmt.Sprintf("Read panicked: %v", e)})
+    }
+  }()
+
+  // iotest.TestReader wants Read(0) to return 0, nil, which io.Reader does
+  // not require, so answer zero-length reads here. The "zero-length reads"
+  // check has already sent them to the reader. Keep Seek visible too.
+  cr := &checkedReader{r: r, check: check, rep: rep, skipEmpty: true}
+  var tr io.Reader = cr
+  if s, ok := r.(io.Seeker); ok {
+    tr = checkedSeeker{cr, s}
+  }
+  if err := iotest.TestReader(tr, want); err != nil && !errors.Is(err, errStalled) {
+    rep.add(Violation{Check: check, Problem: err.Error()})
+  }
+}
+
+// read reads up to limit bytes from r, size bytes at a time, and reports
+// whether it reached io.EOF. If timeout is set, r is wrapped in
+// iotest.TimeoutReader, which fails one read on purpose. Violations go to
+// cr's report.
+func read(r io.Reader, cr *checkedReader, size int, zero, timeout bool, limit int) (got []byte, eof bool) {
+  defer func() {
+    if e := recover(); e != nil {
+      cr.rep.add(Violation{Check: cr.check, Problem: fmt.Sprintf("Read panicked after %d bytes: %v", len(got), e)})
+      got, eof = nil, false
+    }
+  }()
+
+  p := make([]byte, size)
+  for len(got) < limit {
+    if zero {
+      r.Read(p[:0])
+    }
+    n, err := r.Read(p)
+    got = append(got, p[:n]...)
+
+    switch {
+    case err == io.EOF:
+      return got, true
+    case errors.Is(err, iotest.ErrTimeout) && timeout:
+      timeout = false
+    case errors.Is(err, errStalled):
+      return got, false
+    case err != nil:
+      cr.rep.add(Violation{Check: cr.check, Problem: fmt.Sprintf("stream failed after %d bytes: %v", len(got), err)})
+      return got, false
+    }
+  }
+  return got[:limit], false
+}
+
+// compare records a violation if got differs from want.
+func compare(rep *Report, check string, got, want []byte, what string) {
+  if bytes.Equal(got, want) {
+    return
+  }
+  i := 0
+  for i < len(got) && i < len(want) && got[i] == want[i] {
+    i++
+  }
+  var problem string
+  switch {
+  case i == len(got):
+    problem = fmt.Sprintf("read %d bytes, want %d as from %s", len(got), len(want), what)
+  case i == len(want):
+    problem = fmt.Sprintf("read %d bytes, more than the %d from %s", len(got), len(want), what)
+  default:
+    problem = fmt.Sprintf("byte %d is %#02x, want %#02x as from %s", i, got[i], want[i], what)
+  }
+  rep.add(Violation{Check: check, Problem: problem})
+}
+
+// checkedReader sits between the reader under test and whatever reads
+// from it, checking each call.
+type checkedReader struct {
+  r      io.Reader
+  check  string
+  rep    *Report
+  calls  int
+  stalls int  // consecutive calls returning 0, nil
+  eof    bool // the reader has returned io.EOF
+
+  skipEmpty bool // return 0, nil for zero-length reads without calling r
+}
+
+func (c *checkedReader) Read(p []byte) (int, error) {
+  if c.skipEmpty && len(p) == 0 {
+    return 0, nil
+  }
+  c.calls++
+  n, err := c.r.Read(p)
+
+  fail := func(problem string) {
+    c.rep.add(Violation{Check: c.check, Call: c.calls, Len: len(p), N: n, Err: err, Problem: problem})
+  }
+  switch {
+  case n < 0:
+    fail("negative count")
+    n = 0
+  case n > len(p):
+    fail("count exceeds len(p)")
+    n = len(p)
+  }
+
+  switch {
+  case c.eof && len(p) > 0 && (n > 0 || err != io.EOF):
+    fail("io.EOF was returned before, so Read must keep returning 0, io.EOF")
+  case n > 0 && err != nil && err != io.EOF:
+    fail("returned data along with an unexpected error")
+  }
+
+  if err == io.EOF {
+    c.eof = true
+  }
+
+  switch {
+  case n > 0 || err != nil:
+    c.stalls = 0
+  case len(p) == 0:
+    // Returning 0, nil is expected here, and says nothing about progress.
+  case c.stalls+1 == stallLimit:
+    c.stalls = 0
+    fail(fmt.Sprintf("no progress: %d reads in a row returned 0, nil", stallLimit))
+    return 0, errStalled
+  default:
+    c.stalls++
+  }
+  return n, err
+}
+
+// probeEOF reads twice more from a reader that has returned io.EOF, to
+// check that it keeps doing so.
+func (c *checkedReader) probeEOF() {
+  if !c.eof {
+    return
+  }
+  p := make([]byte, 16)
+  c.Read(p)
+  c.Read(p)
+}
+
+// checkedSeeker is a checkedReader whose reader can seek. Seeking starts
+// the stream afresh, so io.EOF no longer has to stick.
+type checkedSeeker struct {
+  *checkedReader
+  s io.Seeker
+}
+
+func (c checkedSeeker) Seek(offset int64, whence int) (int64, error) {
+  c.eof = false
+  return c.s.Seek(offset, whence)
+}
@@ -0,0 +1,200 @@
+package readertest
+
+import (
+  "bytes"
+  "errors"
+  "io"
+  "strings"
+  "testing"
+  "testing/iotest"
+)
+
+const text = "The quick brown fox jumps over the lazy dog."
+
+func TestGoodReaders(t *testing.T) {
+  Test(t, func() io.Reader { return strings.NewReader(text) }, []byte(text))
+  Test(t, func() io.Reader { return bytes.NewBufferString(text) }, nil)
+  Test(t, func() io.Reader { return strings.NewReader("") }, []byte{})
+  Test(t, func() io.Reader {
+    return io.MultiReader(strings.NewReader("ab"), strings.NewReader(""), strings.NewReader("cd"))
+  }, []byte("abcd"))
+
+  // io.Reader lets a zero-length read report an error.
+  Test(t, func() io.Reader { return touchy{strings.NewReader(text)} }, []byte(text))
+
+  // An endless reader is checked up to MaxBytes.
+  rep := Checker{MaxBytes: 1000}.Check(func() io.Reader { return endless{} }, nil)
+  if !rep.OK() {
+    t.Error(rep)
+  }
+}
+
+// touchy fails zero-length reads but otherwise reads normally.
+type touchy struct{ r *strings.Reader }
+
+func (t touchy) Read(p []byte) (int, error) {
+  if len(p) == 0 {
+    return 0, errors.New("nothing to read into")
+  }
+  return t.r.Read(p)
+}
+
+type endless struct{}
+
+func (endless) Read(p []byte) (int, error) {
+  for i := range p {
+    p[i] = 'A'
+  }
+  return len(p), nil
+}
+
+// overcount claims to have read one byte more than fits in p.
+type overcount struct{ r io.Reader }
+
+func (o overcount) Read(p []byte) (int, error) {
+  n, err := o.r.Read(p)
+  if n > 0 {
+    n = len(p) + 1
+  }
+  return n, err
+}
+
+// forgetful returns io.EOF once, then starts the stream over.
+type forgetful struct {
+  s    string
+  r    *strings.Reader
+  done bool
+}
+
+func (f *forgetful) Read(p []byte) (int, error) {
+  if f.r == nil {
+    f.r = strings.NewReader(f.s)
+  }
+  n, err := f.r.Read(p)
+  if err == io.EOF && !f.done {
+    f.done = true
+    f.r = nil
+  }
+  return n, err
+}
+
+// grabby consumes a byte even for zero-length reads.
+type grabby struct{ r *strings.Reader }
+
+func (g grabby) Read(p []byte) (int, error) {
+  if len(p) == 0 {
+    g.r.ReadByte()
+    return 0, nil
+  }
+  return g.r.Read(p)
+}
+
+// stalled never makes progress.
+type stalled struct{}
+
+func (stalled) Read(p []byte) (int, error) { return 0, nil }
+
+// broken returns its data together with an error.
+type broken struct{ r *strings.Reader }
+
+func (b broken) Read(p []byte) (int, error) {
+  n, err := b.r.Read(p)
+  if n > 0 && b.r.Len() == 0 {
+    return n, errors.New("disk on fire")
+  }
+  return n, err
+}
+
+// panicky panics when asked for exactly one byte.
+type panicky struct{ r *strings.Reader }
+
+func (p panicky) Read(b []byte) (int, error) {
+  if len(b) == 1 {
+    panic("one byte is not enough")
+  }
+  return p.r.Read(b)
+}
+
+func TestBadReaders(t *testing.T) {
+  tests := []struct {
+    name      string
+    newReader func() io.Reader
+    want      []byte
+    check     string
+    problem   string
+  }{
+    {
+      name:      "overcount",
+      newReader: func() io.Reader { return overcount{strings.NewReader(text)} },
+      check:     "one-byte reads",
+      problem:   "count exceeds len(p)",
+    },
+    {
+      name:      "forgetful",
+      newReader: func() io.Reader { return &forgetful{s: text} },
+      check:     "plain reads",
+      problem:   "io.EOF was returned before, so Read must keep returning 0, io.EOF",
+    },
+    {
+      name:      "grabby",
+      newReader: func() io.Reader { return grabby{strings.NewReader(text)} },
+      want:      []byte(text),
+      check:     "zero-length reads",
+      problem:   "byte 0 is 0x68, want 0x54 as from the wanted stream",
+    },
+    {
+      name:      "stalled",
+      newReader: func() io.Reader { return stalled{} },
+      check:     "plain reads",
+      problem:   "no progress: 100 reads in a row returned 0, nil",
+    },
+    {
+      name:      "broken",
+      newReader: func() io.Reader { return broken{strings.NewReader(text)} },
+      check:     "plain reads",
+      problem:   "returned data along with an unexpected error",
+    },
+    {
+      name:      "panicky",
+      newReader: func() io.Reader { return panicky{strings.NewReader(text)} },
+      check:     "one-byte reads",
+      problem:   "Read panicked after 0 bytes: one byte is not enough",
+    },
+    {
+      name:      "short",
+      newReader: func() io.Reader { return strings.NewReader(text[:10]) },
+      want:      []byte(text),
+      check:     "plain reads",
+      problem:   "read 10 bytes, want 44 as from the wanted stream",
+    },
+    {
+      name:      "timeout",
+      newReader: func() io.Reader { return iotest.TimeoutReader(strings.NewReader(text)) },
+      check:     "plain reads",
+      problem:   "stream failed after 44 bytes: timeout",
+    },
+  }
+  for _, tt := range tests {
+    rep := Check(tt.newReader, tt.want)
+    found := false
+    for _, v := range rep.Violations {
+      if v.Check == tt.check && v.Problem == tt.problem {
+        found = true
+      }
+    }
+    if !found {
+      t.Errorf("%s: no %q violation in %q; got:\n%v", tt.name, tt.check, tt.problem, rep)
+    }
+    if rep.Err() == nil {
+      t.Errorf("%s: Err() = nil", tt.name)
+    }
+  }
+}
+
+func TestViolationString(t *testing.T) {
+  v := Violation{Check: "one-byte reads", Call: 3, Len: 1, N: 2, Problem: "count exceeds len(p)"}
+  want := "one-byte reads: call 3: Read(1-byte buffer) = 2, <nil>: count exceeds len(p)"
+  if v.String() != want {
+    t.Errorf("String() = %q, want %q", v.String(), want)
+  }
+}
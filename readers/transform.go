@@ -0,0 +1,222 @@
+package readers
+
+import (
+  "io"
+  "slices"
+  "unicode"
+  "unicode/utf8"
+)
+
+// A Mapping rewrites text either a byte or a rune at a time; exactly one
+// of Byte and Rune should be set. Byte mappings suit ASCII ciphers and
+// keep every byte in place. Rune mappings decode UTF-8, so they can
+// handle any letter; bytes that are not valid UTF-8 pass through
+// unchanged, and as with strings.Map, a rune mapped to a negative value
+// is dropped.
+type Mapping struct {
+  Byte func(byte) byte
+  Rune func(rune) rune
+}
+
+// Rot13 replaces each ASCII letter with the one 13 places after it in the
+// alphabet. Applying it twice gives back the original text.
+var Rot13 = Caesar(13)
+
+// Atbash replaces each ASCII letter with the one at the same position
+// from the other end of the alphabet, so "a" becomes "z". Like Rot13 it is
+// its own inverse.
+var Atbash = Mapping{Byte: atbash}
+
+// SwapCase turns upper case letters into lower case and vice versa,
+// for any letter in Unicode.
+var SwapCase = Mapping{Rune: swapCase}
+
+// Caesar shifts each ASCII letter n places along the alphabet, wrapping
+// from "z" back to "a". Caesar(-n) undoes Caesar(n).
+func Caesar(n int) Mapping {
+  shift := byte((n%26 + 26) % 26)
+  return Mapping{Byte: func(b byte) byte {
+    switch {
+    case 'a' <= b && b <= 'z':
+      return 'a' + (b-'a'+shift)%26
+    case 'A' <= b && b <= 'Z':
+      return 'A' + (b-'A'+shift)%26
+    }
+    return b
+  }}
+}
+
+func atbash(b byte) byte {
+  switch {
+  case 'a' <= b && b <= 'z':
+    return 'z' - (b - 'a')
+  case 'A' <= b && b <= 'Z':
+    return 'Z' - (b - 'A')
+  }
+  return b
+}
+
+func swapCase(r rune) rune {
+  switch {
+  case unicode.IsUpper(r):
+    return unicode.ToLower(r)
+  case unicode.IsLower(r):
+    return unicode.ToUpper(r)
+  }
+  return r
+}
+
+// mapRunes appends the mapped form of the complete UTF-8 sequences in src
+// to dst, returning it along with any incomplete sequence left at the end
+// of src. If final is set, src is the end of the stream and nothing is
+// left over.
+func (m Mapping) mapRunes(dst, src []byte, final bool) (out, rest []byte) {
+  for len(src) > 0 {
+    if !final && !utf8.FullRune(src) {
+      break
+    }
+    r, size := utf8.DecodeRune(src)
+    if r == utf8.RuneError && size <= 1 {
+      dst = append(dst, src[0])
+      src = src[1:]
+      continue
+    }
+    if r = m.Rune(r); r >= 0 {
+      dst = utf8.AppendRune(dst, r)
+    }
+    src = src[size:]
+  }
+  return dst, src
+}
+
+// TransformReader is an io.Reader that applies a Mapping to everything
+// read from another reader.
+type TransformReader struct {
+  r   io.Reader
+  m   Mapping
+  buf []byte // rune mode: bytes read but not yet mapped
+  out []byte // rune mode: mapped bytes not yet returned
+  err error
+}
+
+// NewTransformReader returns a reader that reads from r, applying m.
+func NewTransformReader(r io.Reader, m Mapping) *TransformReader {
+  return &TransformReader{r: r, m: m}
+}
+
+// Read reads from the underlying reader and maps what it got. A byte
+// mapping works in place, so each call with a non-empty p makes exactly
+// one call to the underlying reader and returns its count and error. A rune mapping
+// holds back a rune split across reads until the rest of it arrives.
+func (t *TransformReader) Read(p []byte) (int, error) {
+  if len(p) == 0 {
+    return 0, nil
+  }
+  if t.m.Rune == nil {
+    n, err := t.r.Read(p)
+    for i := range p[:n] {
+      p[i] = t.m.Byte(p[i])
+    }
+    return n, err
+  }
+
+  for len(t.out) == 0 {
+    if t.err != nil {
+      return 0, t.readErr()
+    }
+
+    start := len(t.buf)
+    t.buf = slices.Grow(t.buf, max(len(p), 512))
+    t.buf = t.buf[:cap(t.buf)]
+    n, err := t.r.Read(t.buf[start:])
+    t.buf, t.err = t.buf[:start+n], err
+
+    var rest []byte
+    t.out, rest = t.m.mapRunes(t.out[:0], t.buf, err == io.EOF)
+    t.buf = append(t.buf[:0], rest...)
+
+    if n == 0 && err == nil {
+      // Let the caller decide whether to keep trying.
+      return 0, nil
+    }
+  }
+
+  n := copy(p, t.out)
+  t.out = t.out[n:]
+  if len(t.out) == 0 && t.err != nil {
+    return n, t.readErr()
+  }
+  return n, nil
+}
+
+// readErr returns the pending error. Only io.EOF is kept for later calls,
+// so that a reader which timed out can be read again.
+func (t *TransformReader) readErr() error {
+  err := t.err
+  if err != io.EOF {
+    t.err = nil
+  }
+  return err
+}
+
+// TransformWriter is an io.Writer that applies a Mapping to everything
+// written to it before passing it on to another writer. The caller's
+// buffer is never modified.
+type TransformWriter struct {
+  w   io.Writer
+  m   Mapping
+  buf []byte // rune mode: the start of a rune split across writes
+  out []byte
+  err error
+}
+
+// NewTransformWriter returns a writer that applies m and writes to w. With
+// a rune mapping, call Close after the last write to flush any incomplete
+// rune.
+func NewTransformWriter(w io.Writer, m Mapping) *TransformWriter {
+  return &TransformWriter{w: w, m: m}
+}
+
+// Write maps p and writes the result to the underlying writer. With a
+// byte mapping the count is of the bytes actually written. With a rune
+// mapping a failed write reports 0, since mapped runes may differ in
+// length from the input. Once a write fails, later ones return the same
+// error.
+func (t *TransformWriter) Write(p []byte) (int, error) {
+  if t.err != nil {
+    return 0, t.err
+  }
+
+  if t.m.Rune == nil {
+    t.out = t.out[:0]
+    for _, b := range p {
+      t.out = append(t.out, t.m.Byte(b))
+    }
+    n, err := t.w.Write(t.out)
+    t.err = err
+    return n, err
+  }
+
+  t.buf = append(t.buf, p...)
+  var rest []byte
+  t.out, rest = t.m.mapRunes(t.out[:0], t.buf, false)
+  t.buf = append(t.buf[:0], rest...)
+
+  if _, err := t.w.Write(t.out); err != nil {
+    t.err = err
+    return 0, err
+  }
+  return len(p), nil
+}
+
+// Close writes out the bytes of any incomplete rune held back by a rune
+// mapping. It does not close the underlying writer.
+func (t *TransformWriter) Close() error {
+  if t.err != nil || len(t.buf) == 0 {
+    return t.err
+  }
+  t.out, _ = t.m.mapRunes(t.out[:0], t.buf, true)
+  t.buf = t.buf[:0]
+  _, t.err = t.w.Write(t.out)
+  return t.err
+}
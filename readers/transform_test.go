@@ -0,0 +1,152 @@
+package readers
+
+import (
+  "bytes"
+  "errors"
+  "io"
+  "strings"
+  "testing"
+  "testing/iotest"
+)
+
+var mappingTests = []struct {
+  name    string
+  m       Mapping
+  in, out string
+}{
+  {"rot13", Rot13, "Lbh penpxrq gur pbqr!", "You cracked the code!"},
+  {"caesar3", Caesar(3), "Veni, vidi, vici. XYZ", "Yhql, ylgl, ylfl. ABC"},
+  {"caesar-3", Caesar(-3), "Yhql, ylgl, ylfl. ABC", "Veni, vidi, vici. XYZ"},
+  {"caesar29", Caesar(29), "abc", "def"},
+  {"atbash", Atbash, "Hello, World", "Svool, Dliow"},
+  {"swapcase", SwapCase, "Hello, Ωμέγα! ǅ ß", "hELLO, ωΜΈΓΑ! ǅ ß"},
+  {"swapcase-invalid", SwapCase, "a\xffB\xe2\x82", "A\xffb\xe2\x82"},
+  {"drop", Mapping{Rune: func(r rune) rune {
+    if r == 'é' {
+      return -1
+    }
+    return r
+  }}, "café olé", "caf ol"},
+}
+
+func TestTransformReader(t *testing.T) {
+  for _, tt := range mappingTests {
+    // One-byte reads split every multi-byte rune across calls.
+    wrappers := map[string]func(io.Reader) io.Reader{
+      "plain":   func(r io.Reader) io.Reader { return r },
+      "onebyte": iotest.OneByteReader,
+      "half":    iotest.HalfReader,
+      "dataerr": iotest.DataErrReader,
+    }
+    for wname, wrap := range wrappers {
+      r := NewTransformReader(wrap(strings.NewReader(tt.in)), tt.m)
+      if err := iotest.TestReader(r, []byte(tt.out)); err != nil {
+        t.Errorf("%s/%s: %v", tt.name, wname, err)
+      }
+    }
+  }
+}
+
+func TestTransformReaderSmallBuffer(t *testing.T) {
+  // A rune that grows when mapped must survive a 1-byte destination.
+  r := NewTransformReader(strings.NewReader("ıi"), SwapCase)
+  var got []byte
+  p := make([]byte, 1)
+  for {
+    n, err := r.Read(p)
+    got = append(got, p[:n]...)
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      t.Fatal(err)
+    }
+  }
+  if string(got) != "II" {
+    t.Errorf("read %q, want %q", got, "II")
+  }
+}
+
+func TestTransformReaderError(t *testing.T) {
+  boom := errors.New("boom")
+  tests := []struct {
+    m    Mapping
+    want string
+  }{
+    {Rot13, "nopqrs"},
+    {SwapCase, "ABCDEF"},
+  }
+  for _, tt := range tests {
+    src := io.MultiReader(strings.NewReader("abc"), iotest.ErrReader(boom))
+    got, err := io.ReadAll(NewTransformReader(src, tt.m))
+    if !errors.Is(err, boom) || string(got) != tt.want[:3] {
+      t.Errorf("ReadAll = %q, %v, want %q, %v", got, err, tt.want[:3], boom)
+    }
+
+    // A timeout is passed on, and reading again picks up where it left off.
+    r := NewTransformReader(iotest.TimeoutReader(strings.NewReader("abcdef")), tt.m)
+    got = nil
+    p := make([]byte, 3)
+    for {
+      n, err := r.Read(p)
+      got = append(got, p[:n]...)
+      if errors.Is(err, iotest.ErrTimeout) {
+        break
+      }
+      if err != nil {
+        t.Fatalf("Read error = %v, want %v", err, iotest.ErrTimeout)
+      }
+    }
+    rest, err := io.ReadAll(r)
+    if got = append(got, rest...); err != nil || string(got) != tt.want {
+      t.Errorf("reading around a timeout = %q, %v, want %q", got, err, tt.want)
+    }
+  }
+}
+
+func TestTransformWriter(t *testing.T) {
+  for _, tt := range mappingTests {
+    var b bytes.Buffer
+    w := NewTransformWriter(&b, tt.m)
+    in := []byte(tt.in)
+    orig := string(in)
+
+    // Write a byte at a time to split runes across writes.
+    for i := range in {
+      if n, err := w.Write(in[i : i+1]); n != 1 || err != nil {
+        t.Fatalf("%s: Write = %d, %v", tt.name, n, err)
+      }
+    }
+    if err := w.Close(); err != nil {
+      t.Fatal(err)
+    }
+    if b.String() != tt.out {
+      t.Errorf("%s: wrote %q, want %q", tt.name, b.String(), tt.out)
+    }
+    if string(in) != orig {
+      t.Errorf("%s: Write modified its argument", tt.name)
+    }
+  }
+}
+
+type failWriter struct{ n int }
+
+func (w *failWriter) Write(p []byte) (int, error) {
+  if len(p) > w.n {
+    n := w.n
+    w.n = 0
+    return n, errors.New("full")
+  }
+  w.n -= len(p)
+  return len(p), nil
+}
+
+func TestTransformWriterError(t *testing.T) {
+  w := NewTransformWriter(&failWriter{n: 2}, Rot13)
+  if n, err := w.Write([]byte("abcd")); n != 2 || err == nil {
+    t.Errorf("Write = %d, %v, want 2 and an error", n, err)
+  }
+  if n, err := w.Write([]byte("e")); n != 0 || err == nil {
+    t.Errorf("Write after a failure = %d, %v, want 0 and the error", n, err)
+  }
+}
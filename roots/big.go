@@ -0,0 +1,76 @@
+package roots
+
+import (
+  "fmt"
+  "math"
+  "math/big"
+)
+
+// guardBits is the extra precision BigSqrt iterates with so that the
+// result is still accurate after rounding to the requested precision.
+const guardBits = 32
+
+// BigSqrt returns the square root of x rounded to prec bits of mantissa,
+// using the same Newton step as Sqrt: z -= (z*z - x) / 2z. A prec of 0
+// uses the precision of x. Negative x returns an ErrNegativeSqrt.
+func BigSqrt(x *big.Float, prec uint) (*big.Float, error) {
+  if prec == 0 {
+    prec = x.Prec()
+  }
+
+  switch {
+  case x.Sign() < 0:
+    f, _ := x.Float64()
+    return nil, ErrNegativeSqrt(f)
+  case x.Sign() == 0 || x.IsInf():
+    return new(big.Float).SetPrec(prec).Set(x), nil
+  }
+
+  work := prec + guardBits
+
+  // Start from the float64 root of x's mantissa, scaled by half of x's
+  // exponent, which is already correct to about 53 bits.
+  mant := new(big.Float)
+  exp := x.MantExp(mant)
+  m, _ := mant.Float64()
+  if exp%2 != 0 {
+    m *= 2
+    exp--
+  }
+  r, _ := Sqrt(m)
+  z := new(big.Float).SetMantExp(big.NewFloat(r), exp/2).SetPrec(work)
+
+  xx := new(big.Float).SetPrec(work).Set(x)
+  t := new(big.Float).SetPrec(work)
+  d := new(big.Float).SetPrec(work)
+
+  // Each step doubles the number of correct bits, so starting from 53 the
+  // limit is never reached for any precision big.Float can hold.
+  for i := 0; i < 64; i++ {
+    t.Mul(z, z)
+    t.Sub(t, xx)
+    d.Add(z, z)
+    t.Quo(t, d)
+    z.Sub(z, t)
+
+    // Once the step is below the requested precision the next one would
+    // only change the guard bits, so stop rather than chase rounding noise.
+    if t.Sign() == 0 || t.MantExp(nil) <= z.MantExp(nil)-int(prec)-guardBits/2 {
+      return z.SetPrec(prec), nil
+    }
+  }
+
+  return nil, fmt.Errorf("%w computing BigSqrt to %d bits", ErrNoConvergence, prec)
+}
+
+// RatSqrt returns the square root of the exact rational x rounded to prec
+// bits of mantissa.
+func RatSqrt(x *big.Rat, prec uint) (*big.Float, error) {
+  return BigSqrt(new(big.Float).SetPrec(prec+guardBits).SetRat(x), prec)
+}
+
+// DigitsPrec returns the number of mantissa bits needed to represent n
+// significant decimal digits.
+func DigitsPrec(n int) uint {
+  return uint(math.Ceil(float64(n) * math.Log2(10)))
+}
@@ -0,0 +1,58 @@
+package roots
+
+import (
+  "errors"
+  "math/big"
+  "testing"
+)
+
+func TestBigSqrt(t *testing.T) {
+  for _, prec := range []uint{24, 53, 64, 200, 1000, 10000} {
+    for _, s := range []string{"2", "0.5", "3", "1e-400", "12345678901234567890123456789", "1e1000"} {
+      x, _, err := big.ParseFloat(s, 10, prec+64, big.ToNearestEven)
+      if err != nil {
+        t.Fatal(err)
+      }
+
+      got, err := BigSqrt(x, prec)
+      if err != nil {
+        t.Fatalf("BigSqrt(%s, %d): %v", s, prec, err)
+      }
+      want := new(big.Float).SetPrec(prec).Sqrt(x)
+
+      if got.Prec() != prec {
+        t.Errorf("BigSqrt(%s, %d) has precision %d", s, prec, got.Prec())
+      }
+      if got.Cmp(want) != 0 {
+        t.Errorf("BigSqrt(%s, %d) = %s, want %s", s, prec, got.Text('g', 20), want.Text('g', 20))
+      }
+    }
+  }
+}
+
+func TestBigSqrtSpecial(t *testing.T) {
+  got, err := BigSqrt(new(big.Float), 100)
+  if err != nil || got.Sign() != 0 {
+    t.Errorf("BigSqrt(0) = %v, %v; want 0, nil", got, err)
+  }
+
+  _, err = BigSqrt(big.NewFloat(-4), 100)
+  var neg ErrNegativeSqrt
+  if !errors.As(err, &neg) || neg != -4 {
+    t.Errorf("BigSqrt(-4) error = %v, want ErrNegativeSqrt(-4)", err)
+  }
+}
+
+func TestRatSqrt(t *testing.T) {
+  // √(1/9) = 1/3, which is not exactly representable, so compare against
+  // 1/3 rounded to the same precision.
+  got, err := RatSqrt(big.NewRat(1, 9), 300)
+  if err != nil {
+    t.Fatal(err)
+  }
+  want := new(big.Float).SetPrec(300).SetRat(big.NewRat(1, 3))
+
+  if got.Cmp(want) != 0 {
+    t.Errorf("RatSqrt(1/9) = %s, want %s", got.Text('g', 50), want.Text('g', 50))
+  }
+}
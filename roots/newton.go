@@ -0,0 +1,89 @@
+// Package roots finds roots of real functions with Newton's method and
+// builds square and cube roots on top of it.
+package roots
+
+import (
+  "errors"
+  "fmt"
+  "math"
+)
+
+const (
+  // DefaultTolerance is the relative step size below which Newton
+  // considers itself converged: a few units in the last place.
+  DefaultTolerance = 4 * 0x1p-52
+
+  // DefaultMaxIter bounds the number of steps Newton takes.
+  DefaultMaxIter = 100
+)
+
+var (
+  // ErrNoConvergence is returned when the iteration limit is reached
+  // before the steps become small enough.
+  ErrNoConvergence = errors.New("roots: no convergence")
+
+  // ErrZeroDerivative is returned when f′ vanishes, leaving Newton with
+  // nowhere to step.
+  ErrZeroDerivative = errors.New("roots: zero derivative")
+
+  // ErrNotFinite is returned when an iterate becomes NaN or infinite.
+  ErrNotFinite = errors.New("roots: iterate is not finite")
+)
+
+// Newton configures a Newton–Raphson solver. The zero value is ready to
+// use with the default tolerance and iteration limit.
+type Newton struct {
+  // Tolerance is the relative change between two iterates at which the
+  // solver stops. Zero means DefaultTolerance.
+  Tolerance float64
+
+  // MaxIter is the maximum number of steps taken. Zero means
+  // DefaultMaxIter.
+  MaxIter int
+}
+
+// Solve looks for a root of f starting from x0, using df as the derivative
+// of f. It returns the root, the number of steps taken and, if the method
+// failed, an error wrapping one of ErrNoConvergence, ErrZeroDerivative or
+// ErrNotFinite along with the last iterate.
+func (n Newton) Solve(f, df func(float64) float64, x0 float64) (float64, int, error) {
+  tol := n.Tolerance
+  if tol <= 0 {
+    tol = DefaultTolerance
+  }
+
+  maxIter := n.MaxIter
+  if maxIter <= 0 {
+    maxIter = DefaultMaxIter
+  }
+
+  z := x0
+  for i := 1; i <= maxIter; i++ {
+    fz := f(z)
+    if fz == 0 {
+      return z, i - 1, nil
+    }
+
+    d := df(z)
+    if d == 0 {
+      return z, i, fmt.Errorf("%w at x = %g", ErrZeroDerivative, z)
+    }
+
+    zz := z - fz/d
+    if math.IsNaN(zz) || math.IsInf(zz, 0) {
+      return z, i, fmt.Errorf("%w after step from x = %g", ErrNotFinite, z)
+    }
+
+    if math.Abs(zz-z) <= tol*math.Abs(zz) {
+      return zz, i, nil
+    }
+    z = zz
+  }
+
+  return z, maxIter, fmt.Errorf("%w after %d iterations, last x = %g", ErrNoConvergence, maxIter, z)
+}
+
+// Solve is shorthand for Newton{}.Solve(f, df, x0).
+func Solve(f, df func(float64) float64, x0 float64) (float64, int, error) {
+  return Newton{}.Solve(f, df, x0)
+}
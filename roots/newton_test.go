@@ -0,0 +1,96 @@
+package roots
+
+import (
+  "errors"
+  "math"
+  "testing"
+)
+
+func TestNewtonSolve(t *testing.T) {
+  // x² - 2 has a root at √2.
+  f := func(x float64) float64 { return x*x - 2 }
+  df := func(x float64) float64 { return 2 * x }
+
+  root, iter, err := Solve(f, df, 1)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if math.Abs(root-math.Sqrt2) > 1e-15 {
+    t.Errorf("root = %v, want %v", root, math.Sqrt2)
+  }
+  if iter < 1 || iter > 10 {
+    t.Errorf("took %d iterations, want between 1 and 10", iter)
+  }
+}
+
+func TestNewtonExactRoot(t *testing.T) {
+  root, iter, err := Solve(
+    func(x float64) float64 { return x - 3 },
+    func(x float64) float64 { return 1 },
+    3,
+  )
+  if err != nil || root != 3 || iter != 0 {
+    t.Errorf("Solve = %v, %d, %v; want 3, 0, nil", root, iter, err)
+  }
+}
+
+func TestNewtonErrors(t *testing.T) {
+  tests := []struct {
+    name   string
+    solver Newton
+    f, df  func(float64) float64
+    x0     float64
+    want   error
+  }{
+    {
+      // x³ - 2x + 2 cycles between 0 and 1 forever from x0 = 0.
+      name:   "cycle",
+      solver: Newton{MaxIter: 50},
+      f:      func(x float64) float64 { return x*x*x - 2*x + 2 },
+      df:     func(x float64) float64 { return 3*x*x - 2 },
+      x0:     0,
+      want:   ErrNoConvergence,
+    },
+    {
+      name: "flat",
+      f:    func(x float64) float64 { return x*x + 1 },
+      df:   func(x float64) float64 { return 2 * x },
+      x0:   0,
+      want: ErrZeroDerivative,
+    },
+    {
+      name: "overflow",
+      f:    func(x float64) float64 { return math.Exp(x) - 1 },
+      df:   func(x float64) float64 { return math.Exp(x) },
+      x0:   -700,
+      want: ErrNotFinite,
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      _, _, err := tt.solver.Solve(tt.f, tt.df, tt.x0)
+      if !errors.Is(err, tt.want) {
+        t.Errorf("err = %v, want %v", err, tt.want)
+      }
+    })
+  }
+}
+
+func TestNewtonTolerance(t *testing.T) {
+  f := func(x float64) float64 { return x*x - 2 }
+  df := func(x float64) float64 { return 2 * x }
+
+  _, loose, err := Newton{Tolerance: 1e-3}.Solve(f, df, 1)
+  if err != nil {
+    t.Fatal(err)
+  }
+  _, tight, err := Newton{}.Solve(f, df, 1)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if loose >= tight {
+    t.Errorf("loose tolerance took %d iterations, default took %d", loose, tight)
+  }
+}
@@ -0,0 +1,96 @@
+package roots
+
+import (
+  "fmt"
+  "math"
+  "math/cmplx"
+)
+
+// ErrNegativeSqrt is returned by Sqrt when asked for the root of a negative
+// number, which has no real square root.
+type ErrNegativeSqrt float64
+
+// Error converts e to a float64 before formatting it; passing e itself to
+// fmt would call Error again and recurse forever.
+func (e ErrNegativeSqrt) Error() string {
+  return fmt.Sprintf("cannot Sqrt negative number: %v", float64(e))
+}
+
+// Sqrt returns the square root of x using Newton's method. Negative x
+// returns 0 and an ErrNegativeSqrt.
+//
+// x is first split into m·2^e with e even and m in [0.5, 2), so the solver
+// always works on a well-scaled value no matter how large or small x is,
+// then the root of m is scaled back by 2^(e/2).
+func Sqrt(x float64) (float64, error) {
+  switch {
+  case x == 0 || math.IsNaN(x) || math.IsInf(x, 1):
+    return x, nil
+  case x < 0:
+    return 0, ErrNegativeSqrt(x)
+  }
+
+  m, e := math.Frexp(x)
+  if e%2 != 0 {
+    m *= 2
+    e--
+  }
+
+  z, _, err := Solve(
+    func(z float64) float64 { return math.FMA(z, z, -m) },
+    func(z float64) float64 { return 2 * z },
+    1,
+  )
+  if err != nil {
+    // m is always in [0.5, 2), where Newton converges in a handful of
+    // steps, so this can only be a programming error.
+    panic(err)
+  }
+
+  // A step smaller than half a unit in the last place rounds away to
+  // nothing, which can leave z one ulp from the correctly rounded root
+  // when the root sits near a power of two. Settle on whichever neighbour
+  // leaves the smallest residual.
+  for _, n := range []float64{math.Nextafter(z, 0), math.Nextafter(z, math.Inf(1))} {
+    if math.Abs(math.FMA(n, n, -m)) < math.Abs(math.FMA(z, z, -m)) {
+      z = n
+    }
+  }
+
+  return math.Ldexp(z, e/2), nil
+}
+
+// Cbrt returns the cube root of x using Newton's method, scaling x the
+// same way Sqrt does.
+func Cbrt(x float64) float64 {
+  switch {
+  case x == 0 || math.IsNaN(x) || math.IsInf(x, 0):
+    return x
+  case x < 0:
+    return -Cbrt(-x)
+  }
+
+  m, e := math.Frexp(x)
+  for e%3 != 0 {
+    m *= 2
+    e--
+  }
+
+  z, _, err := Solve(
+    func(z float64) float64 { return math.FMA(z*z, z, -m) },
+    func(z float64) float64 { return 3 * z * z },
+    1,
+  )
+  if err != nil {
+    panic(err)
+  }
+
+  return math.Ldexp(z, e/3)
+}
+
+// Csqrt returns the principal square root of x as a complex number, so a
+// negative x gives a purely imaginary root instead of an error. It is
+// cmplx.Sqrt(complex(x, 0)).
+func Csqrt(x float64) complex128 {
+  return cmplx.Sqrt(complex(x, 0))
+}
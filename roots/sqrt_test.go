@@ -0,0 +1,94 @@
+package roots
+
+import (
+  "errors"
+  "math"
+  "math/cmplx"
+  "testing"
+)
+
+// samples returns values spread across the whole float64 range, from the
+// smallest subnormal to the largest finite value.
+func samples() []float64 {
+  xs := []float64{
+    0, 1, 2, 3, 4, 0.25, 1e-300, 1e300,
+    math.SmallestNonzeroFloat64, math.MaxFloat64,
+  }
+
+  for e := -1074; e <= 1023; e++ {
+    for _, m := range []float64{1, 1.1, math.Pi / 2, 1.9999999} {
+      xs = append(xs, math.Ldexp(m, e))
+    }
+  }
+  return xs
+}
+
+// ulps returns the distance between got and want in units of the last
+// place of want.
+func ulps(got, want float64) float64 {
+  if got == want {
+    return 0
+  }
+  return math.Abs(got-want) / (math.Nextafter(want, math.Inf(1)) - want)
+}
+
+func TestSqrt(t *testing.T) {
+  for _, x := range samples() {
+    got, err := Sqrt(x)
+    if want := math.Sqrt(x); got != want || err != nil {
+      t.Errorf("Sqrt(%g) = %v, %v; want %v, nil", x, got, err, want)
+    }
+  }
+}
+
+func TestSqrtSpecial(t *testing.T) {
+  if got, _ := Sqrt(math.Inf(1)); !math.IsInf(got, 1) {
+    t.Errorf("Sqrt(+Inf) = %v, want +Inf", got)
+  }
+  if got, _ := Sqrt(math.NaN()); !math.IsNaN(got) {
+    t.Errorf("Sqrt(NaN) = %v, want NaN", got)
+  }
+}
+
+func TestSqrtNegative(t *testing.T) {
+  for _, x := range []float64{-2, -1e-300, math.Inf(-1)} {
+    got, err := Sqrt(x)
+    if got != 0 {
+      t.Errorf("Sqrt(%g) = %v, want 0", x, got)
+    }
+
+    var neg ErrNegativeSqrt
+    if !errors.As(err, &neg) || float64(neg) != x {
+      t.Errorf("Sqrt(%g) error = %v, want ErrNegativeSqrt(%g)", x, err, x)
+    }
+  }
+
+  if got, want := ErrNegativeSqrt(-2).Error(), "cannot Sqrt negative number: -2"; got != want {
+    t.Errorf("Error() = %q, want %q", got, want)
+  }
+}
+
+func TestCsqrt(t *testing.T) {
+  for _, x := range samples() {
+    for _, x := range []float64{x, -x} {
+      if got, want := Csqrt(x), cmplx.Sqrt(complex(x, 0)); got != want {
+        t.Errorf("Csqrt(%g) = %v, want %v", x, got, want)
+      }
+    }
+  }
+
+  // == treats -0 and 0 as equal, so check the sign of a zero root apart.
+  if got := Csqrt(math.Copysign(0, -1)); math.Signbit(real(got)) || math.Signbit(imag(got)) {
+    t.Errorf("Csqrt(-0) = %v, want (0+0i)", got)
+  }
+}
+
+func TestCbrt(t *testing.T) {
+  for _, x := range samples() {
+    for _, x := range []float64{x, -x} {
+      if got, want := Cbrt(x), math.Cbrt(x); ulps(got, want) > 1 {
+        t.Errorf("Cbrt(%g) = %v, want %v", x, got, want)
+      }
+    }
+  }
+}
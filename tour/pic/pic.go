@@ -0,0 +1,85 @@
+// Package pic is an offline stand-in for golang.org/x/tour/pic. It renders
+// the output of a Pic function the same way the tour's web page expects it.
+package pic
+
+import (
+  "bufio"
+  "encoding/base64"
+  "fmt"
+  "image"
+  "image/png"
+  "io"
+  "os"
+)
+
+// Show calls f with a 256x256 canvas, checks the shape of the result and
+// prints it as a base64 encoded PNG prefixed with "IMAGE:". Each value is
+// used as the red and green channels of a fully blue, opaque pixel.
+// Failures are reported on stderr.
+func Show(f func(dx, dy int) [][]uint8) {
+  m, err := render(f)
+  if err != nil {
+    fmt.Fprint(os.Stderr, err)
+    return
+  }
+  ShowImage(m)
+}
+
+// Fshow is like Show but writes the image, or the reason it could not be
+// shown, to w.
+func Fshow(w io.Writer, f func(dx, dy int) [][]uint8) {
+  m, err := render(f)
+  if err != nil {
+    fmt.Fprint(w, err)
+    return
+  }
+  FshowImage(w, m)
+}
+
+func render(f func(dx, dy int) [][]uint8) (*image.NRGBA, error) {
+  const (
+    dx = 256
+    dy = 256
+  )
+
+  data := f(dx, dy)
+  if len(data) != dy {
+    return nil, fmt.Errorf("FAIL\n got %d rows, want %d\n", len(data), dy)
+  }
+
+  m := image.NewNRGBA(image.Rect(0, 0, dx, dy))
+  for y := 0; y < dy; y++ {
+    if len(data[y]) != dx {
+      return nil, fmt.Errorf("FAIL\n row %d has %d columns, want %d\n", y, len(data[y]), dx)
+    }
+
+    for x := 0; x < dx; x++ {
+      v := data[y][x]
+      i := y*m.Stride + x*4
+      m.Pix[i] = v
+      m.Pix[i+1] = v
+      m.Pix[i+2] = 255
+      m.Pix[i+3] = 255
+    }
+  }
+  return m, nil
+}
+
+// ShowImage prints m as a base64 encoded PNG prefixed with "IMAGE:".
+func ShowImage(m image.Image) {
+  FshowImage(os.Stdout, m)
+}
+
+// FshowImage is like ShowImage but writes to out.
+func FshowImage(out io.Writer, m image.Image) {
+  w := bufio.NewWriter(out)
+  defer w.Flush()
+
+  io.WriteString(w, "IMAGE:")
+  b64 := base64.NewEncoder(base64.StdEncoding, w)
+  if err := (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(b64, m); err != nil {
+    panic(err)
+  }
+  b64.Close()
+  io.WriteString(w, "\n")
+}
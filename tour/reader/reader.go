@@ -0,0 +1,52 @@
+// Package reader is an offline stand-in for golang.org/x/tour/reader. It
+// checks that a reader emits an infinite stream of 'A' bytes.
+package reader
+
+import (
+  "fmt"
+  "io"
+  "os"
+)
+
+// Validate reads up to 1MB from r, printing "OK!" if every byte is 'A' and
+// the reader never errors. Failures are reported on stderr.
+func Validate(r io.Reader) {
+  if err := validate(r); err != nil {
+    fmt.Fprintln(os.Stderr, err)
+    return
+  }
+  fmt.Println("OK!")
+}
+
+// Fvalidate is like Validate but writes both "OK!" and any failure to w.
+func Fvalidate(w io.Writer, r io.Reader) {
+  if err := validate(r); err != nil {
+    fmt.Fprintln(w, err)
+    return
+  }
+  fmt.Fprintln(w, "OK!")
+}
+
+func validate(r io.Reader) error {
+  b := make([]byte, 1024, 2048)
+  i, o := 0, 0
+
+  for ; i < 1<<20 && o < 1<<20; i++ {
+    n, err := r.Read(b)
+    for j, v := range b[:n] {
+      if v != 'A' {
+        return fmt.Errorf("got byte %x at offset %v, want 'A'", v, o+j)
+      }
+    }
+    o += n
+
+    if err != nil {
+      return fmt.Errorf("read error: %v", err)
+    }
+  }
+
+  if o == 0 {
+    return fmt.Errorf("read zero bytes after %d Read calls", i)
+  }
+  return nil
+}
@@ -0,0 +1,60 @@
+// Package wc is an offline stand-in for golang.org/x/tour/wc. It checks a
+// WordCount implementation against the same cases the tour uses.
+package wc
+
+import (
+  "fmt"
+  "io"
+  "os"
+)
+
+// Test runs f against each test case, printing PASS or FAIL for every one.
+// It stops at the first failure.
+func Test(f func(string) map[string]int) {
+  Ftest(os.Stdout, f)
+}
+
+// Ftest is like Test but writes its report to w.
+func Ftest(w io.Writer, f func(string) map[string]int) {
+  for _, c := range testCases {
+    got := f(c.in)
+
+    if !equal(got, c.want) {
+      fmt.Fprintf(w, "FAIL\n f(%q) =\n  %#v\n want:\n  %#v\n", c.in, got, c.want)
+      return
+    }
+    fmt.Fprintf(w, "PASS\n f(%q) = \n  %#v\n", c.in, got)
+  }
+}
+
+func equal(got, want map[string]int) bool {
+  if len(got) != len(want) {
+    return false
+  }
+
+  for k, v := range want {
+    if n, ok := got[k]; !ok || n != v {
+      return false
+    }
+  }
+  return true
+}
+
+var testCases = []struct {
+  in   string
+  want map[string]int
+}{
+  {"I am learning Go!", map[string]int{
+    "I": 1, "am": 1, "learning": 1, "Go!": 1,
+  }},
+  {"The quick brown fox jumped over the lazy dog.", map[string]int{
+    "The": 1, "quick": 1, "brown": 1, "fox": 1, "jumped": 1,
+    "over": 1, "the": 1, "lazy": 1, "dog.": 1,
+  }},
+  {"I ate a donut. Then I ate another donut.", map[string]int{
+    "I": 2, "ate": 2, "a": 1, "donut.": 2, "Then": 1, "another": 1,
+  }},
+  {"A man a plan a canal panama.", map[string]int{
+    "A": 1, "man": 1, "a": 2, "plan": 1, "canal": 1, "panama.": 1,
+  }},
+}
@@ -0,0 +1,73 @@
+package wordcount
+
+import "github.com/lollar/gotour/wordcount/sketch"
+
+// Counter tallies words. Exact keeps every distinct word; Approx trades
+// accuracy for memory that doesn't grow with the number of distinct words.
+type Counter interface {
+  // Add records one occurrence of word.
+  Add(word string)
+
+  // Count returns the number of times word has been added.
+  Count(word string) int
+
+  // Distinct returns the number of different words added.
+  Distinct() int
+
+  // Total returns the number of words added.
+  Total() int
+}
+
+// Exact is a Counter backed by the same map WordCount returns.
+type Exact map[string]int
+
+func (e Exact) Add(word string)       { e[word]++ }
+func (e Exact) Count(word string) int { return e[word] }
+func (e Exact) Distinct() int         { return len(e) }
+
+func (e Exact) Total() int {
+  total := 0
+  for _, n := range e {
+    total += n
+  }
+  return total
+}
+
+// Approx is a Counter that estimates word counts with a Count-Min sketch
+// and the number of distinct words with HyperLogLog, using a fixed amount
+// of memory however many distinct words it sees. Counts are never
+// underestimated.
+type Approx struct {
+  freq     *sketch.CountMin
+  distinct *sketch.HyperLogLog
+}
+
+// NewApprox returns an Approx counter. With probability 1-delta each count
+// overestimates by at most epsilon times the total number of words, and
+// Distinct has a relative standard error of at most distinctErr. It panics
+// on bounds that sketch.NewCountMin or sketch.NewHyperLogLog reject,
+// including a distinctErr below about 0.00026.
+func NewApprox(epsilon, delta, distinctErr float64) *Approx {
+  return &Approx{
+    freq:     sketch.NewCountMin(epsilon, delta),
+    distinct: sketch.NewHyperLogLog(distinctErr),
+  }
+}
+
+func (a *Approx) Add(word string) {
+  a.freq.Add(word, 1)
+  a.distinct.Add(word)
+}
+
+func (a *Approx) Count(word string) int { return int(a.freq.Count(word)) }
+func (a *Approx) Distinct() int         { return int(a.distinct.Count()) }
+func (a *Approx) Total() int            { return int(a.freq.Total()) }
+
+// Merge adds the words counted by o to a. Both must have been created
+// with the same bounds.
+func (a *Approx) Merge(o *Approx) error {
+  if err := a.freq.Merge(o.freq); err != nil {
+    return err
+  }
+  return a.distinct.Merge(o.distinct)
+}
@@ -0,0 +1,78 @@
+package wordcount
+
+import (
+  "fmt"
+  "math"
+  "math/rand"
+  "strings"
+  "testing"
+)
+
+// zipfText returns n space-separated words drawn from a Zipf distribution,
+// roughly the shape of word frequencies in natural text.
+func zipfText(n, distinct int) string {
+  r := rand.New(rand.NewSource(1))
+  z := rand.NewZipf(r, 1.2, 1, uint64(distinct-1))
+
+  var b strings.Builder
+  for i := 0; i < n; i++ {
+    fmt.Fprintf(&b, "w%d ", z.Uint64())
+  }
+  return b.String()
+}
+
+func TestApproxAgainstExact(t *testing.T) {
+  const epsilon, delta, distinctErr = 0.0005, 0.01, 0.01
+  text := zipfText(100000, 20000)
+  wc := New()
+
+  exact := Exact{}
+  approx := NewApprox(epsilon, delta, distinctErr)
+  for _, c := range []Counter{exact, approx} {
+    if err := wc.CountInto(strings.NewReader(text), c); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  if want := wc.Count(text); len(want) != len(exact) {
+    t.Fatalf("Exact counted %d distinct words, Count found %d", len(exact), len(want))
+  }
+  if approx.Total() != exact.Total() {
+    t.Errorf("Approx.Total = %d, want %d", approx.Total(), exact.Total())
+  }
+
+  bound := epsilon * float64(exact.Total())
+  over := 0
+  for word, want := range exact {
+    got := approx.Count(word)
+    if got < want {
+      t.Fatalf("Approx.Count(%q) = %d, underestimates %d", word, got, want)
+    }
+    if float64(got-want) > bound {
+      over++
+    }
+  }
+  if rate := float64(over) / float64(len(exact)); rate > delta {
+    t.Errorf("%.2f%% of counts exceed the error bound, want at most %.2f%%", 100*rate, 100*delta)
+  }
+
+  got, want := float64(approx.Distinct()), float64(exact.Distinct())
+  if math.Abs(got-want) > 4*distinctErr*want {
+    t.Errorf("Approx.Distinct = %v, want about %v", got, want)
+  }
+}
+
+func TestApproxMerge(t *testing.T) {
+  a, b := NewApprox(0.01, 0.01, 0.05), NewApprox(0.01, 0.01, 0.05)
+  a.Add("go")
+  b.Add("go")
+  b.Add("gopher")
+
+  if err := a.Merge(b); err != nil {
+    t.Fatal(err)
+  }
+  if a.Count("go") != 2 || a.Total() != 3 || a.Distinct() != 2 {
+    t.Errorf("merged counter = go:%d total:%d distinct:%d, want 2, 3, 2",
+      a.Count("go"), a.Total(), a.Distinct())
+  }
+}
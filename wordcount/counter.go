@@ -0,0 +1,154 @@
+package wordcount
+
+import (
+  "bufio"
+  "io"
+  "strings"
+)
+
+// WordCounter counts words using a configurable tokenizer. The zero value
+// is not usable; create one with New.
+type WordCounter struct {
+  split     bufio.SplitFunc
+  normalize []func(string) string
+  stopWords []string
+  stop      map[string]bool
+  n         int
+}
+
+// Option configures a WordCounter.
+type Option func(*WordCounter)
+
+// New returns a WordCounter configured by opts. With no options it splits
+// on whitespace and counts words exactly as they appear, like WordCount.
+func New(opts ...Option) *WordCounter {
+  wc := &WordCounter{split: bufio.ScanWords, n: 1}
+  for _, opt := range opts {
+    opt(wc)
+  }
+
+  // Stop words go through the same normalization as the text, so that
+  // "The" is dropped when case folding is on regardless of option order.
+  if len(wc.stopWords) > 0 {
+    wc.stop = make(map[string]bool, len(wc.stopWords))
+    for _, w := range wc.stopWords {
+      wc.stop[wc.normalized(w)] = true
+    }
+  }
+  return wc
+}
+
+// WithSplit tokenizes input with split instead of splitting on whitespace.
+func WithSplit(split bufio.SplitFunc) Option {
+  return func(wc *WordCounter) {
+    wc.split = split
+  }
+}
+
+// WithUnicodeWords tokenizes input with ScanUnicodeWords.
+func WithUnicodeWords() Option {
+  return WithSplit(ScanUnicodeWords)
+}
+
+// WithNormalizer applies fn to every word before it is counted. Words that
+// fn maps to the empty string are dropped. Normalizers run in the order
+// their options are given.
+func WithNormalizer(fn func(string) string) Option {
+  return func(wc *WordCounter) {
+    wc.normalize = append(wc.normalize, fn)
+  }
+}
+
+// WithPunctuationStripped trims punctuation from both ends of every word.
+func WithPunctuationStripped() Option {
+  return WithNormalizer(StripPunctuation)
+}
+
+// WithCaseFolding counts words that differ only in case as one.
+func WithCaseFolding() Option {
+  return WithNormalizer(FoldCase)
+}
+
+// WithStopWords drops the given words from the counts. Passing the option
+// more than once adds to the list.
+func WithStopWords(words ...string) Option {
+  return func(wc *WordCounter) {
+    wc.stopWords = append(wc.stopWords, words...)
+  }
+}
+
+// WithNGrams counts runs of n consecutive words, joined by single spaces,
+// instead of single words. Stop words are removed before the runs are
+// formed. n less than 1 is treated as 1.
+func WithNGrams(n int) Option {
+  return func(wc *WordCounter) {
+    wc.n = max(n, 1)
+  }
+}
+
+// Count returns the counts of each word in s.
+func (wc *WordCounter) Count(s string) map[string]int {
+  sc := bufio.NewScanner(strings.NewReader(s))
+  // A string is already in memory, so let a single word be as long as
+  // the whole string rather than failing with bufio.ErrTooLong.
+  sc.Buffer(nil, len(s)+1)
+
+  counts, _ := wc.count(sc)
+  return counts
+}
+
+// CountReader returns the counts of each word read from r. Input is read
+// a buffer at a time, so only the distinct words have to fit in memory.
+// Words longer than bufio.MaxScanTokenSize fail with bufio.ErrTooLong.
+func (wc *WordCounter) CountReader(r io.Reader) (map[string]int, error) {
+  return wc.count(bufio.NewScanner(r))
+}
+
+// CountInto feeds every word read from r to c, so the words can be
+// tallied by something other than an exact map, such as an Approx counter.
+func (wc *WordCounter) CountInto(r io.Reader, c Counter) error {
+  return wc.scan(bufio.NewScanner(r), c.Add)
+}
+
+func (wc *WordCounter) count(sc *bufio.Scanner) (map[string]int, error) {
+  wordCount := make(map[string]int)
+  err := wc.scan(sc, func(word string) {
+    wordCount[word]++
+  })
+  return wordCount, err
+}
+
+// scan tokenizes sc and passes each normalized word, or n-gram, to emit.
+func (wc *WordCounter) scan(sc *bufio.Scanner, emit func(string)) error {
+  sc.Split(wc.split)
+
+  var window []string
+  for sc.Scan() {
+    word := wc.normalized(sc.Text())
+    if word == "" || wc.stop[word] {
+      continue
+    }
+
+    if wc.n == 1 {
+      emit(word)
+      continue
+    }
+
+    window = append(window, word)
+    if len(window) > wc.n {
+      window = window[1:]
+    }
+    if len(window) == wc.n {
+      emit(strings.Join(window, " "))
+    }
+  }
+
+  return sc.Err()
+}
+
+func (wc *WordCounter) normalized(word string) string {
+  for _, fn := range wc.normalize {
+    word = fn(word)
+  }
+  return word
+}
@@ -0,0 +1,125 @@
+package wordcount
+
+import (
+  "bufio"
+  "maps"
+  "strings"
+  "testing"
+  "testing/iotest"
+)
+
+func TestWordCounter(t *testing.T) {
+  tests := []struct {
+    name string
+    opts []Option
+    in   string
+    want map[string]int
+  }{
+    {
+      name: "default",
+      in:   "Go, go GO",
+      want: map[string]int{"Go,": 1, "go": 1, "GO": 1},
+    },
+    {
+      name: "punctuation",
+      opts: []Option{WithPunctuationStripped()},
+      in:   "Go, go! (go) -- ...",
+      want: map[string]int{"Go": 1, "go": 2},
+    },
+    {
+      name: "case folding",
+      opts: []Option{WithPunctuationStripped(), WithCaseFolding()},
+      in:   "Go, go! GO. ΣΊΣΥΦΟΣ σίσυφος Hello 世界 世界",
+      want: map[string]int{"go": 3, "σίσυφοσ": 2, "hello": 1, "世界": 2},
+    },
+    {
+      name: "unicode words",
+      opts: []Option{WithUnicodeWords()},
+      in:   "don't stop—well-known, \"quoted\" snake_case 'tis naïve 42nd",
+      want: map[string]int{
+        "don't": 1, "stop": 1, "well-known": 1, "quoted": 1,
+        "snake_case": 1, "tis": 1, "naïve": 1, "42nd": 1,
+      },
+    },
+    {
+      name: "stop words",
+      opts: []Option{WithUnicodeWords(), WithStopWords(EnglishStopWords...), WithCaseFolding()},
+      in:   "The fox and THE dog",
+      want: map[string]int{"fox": 1, "dog": 1},
+    },
+    {
+      name: "bigrams",
+      opts: []Option{WithNGrams(2), WithCaseFolding()},
+      in:   "the cat saw The cat",
+      want: map[string]int{"the cat": 2, "cat saw": 1, "saw the": 1},
+    },
+    {
+      name: "trigrams without stop words",
+      opts: []Option{WithNGrams(3), WithStopWords("a")},
+      in:   "one a two three four",
+      want: map[string]int{"one two three": 1, "two three four": 1},
+    },
+    {
+      name: "ngrams longer than input",
+      opts: []Option{WithNGrams(3)},
+      in:   "too short",
+      want: map[string]int{},
+    },
+    {
+      name: "custom split",
+      opts: []Option{WithSplit(bufio.ScanLines)},
+      in:   "one line\none line\nanother",
+      want: map[string]int{"one line": 2, "another": 1},
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      wc := New(tt.opts...)
+
+      if got := wc.Count(tt.in); !maps.Equal(got, tt.want) {
+        t.Errorf("Count(%q) = %v, want %v", tt.in, got, tt.want)
+      }
+
+      got, err := wc.CountReader(iotest.OneByteReader(strings.NewReader(tt.in)))
+      if err != nil {
+        t.Fatal(err)
+      }
+      if !maps.Equal(got, tt.want) {
+        t.Errorf("CountReader(%q) = %v, want %v", tt.in, got, tt.want)
+      }
+    })
+  }
+}
+
+func TestScanUnicodeWords(t *testing.T) {
+  tests := []struct {
+    in   string
+    want []string
+  }{
+    {"", nil},
+    {"  ,. ", nil},
+    {"Hello, 世界!", []string{"Hello", "世界"}},
+    {"trailing'", []string{"trailing"}},
+    {"it's", []string{"it's"}},
+    {"a--b", []string{"a", "b"}},
+    {"\xffbad\xfe", []string{"bad"}},
+  }
+
+  for _, tt := range tests {
+    sc := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(tt.in)))
+    sc.Split(ScanUnicodeWords)
+
+    var got []string
+    for sc.Scan() {
+      got = append(got, sc.Text())
+    }
+    if sc.Err() != nil {
+      t.Fatal(sc.Err())
+    }
+
+    if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+      t.Errorf("ScanUnicodeWords(%q) = %q, want %q", tt.in, got, tt.want)
+    }
+  }
+}
@@ -0,0 +1,131 @@
+package wordcount
+
+import (
+  "context"
+  "errors"
+  "io"
+  "io/fs"
+  "os"
+  "path/filepath"
+  "runtime"
+  "sync"
+)
+
+// FileError records a file that could not be counted.
+type FileError struct {
+  Path string
+  Err  error
+}
+
+func (e *FileError) Error() string {
+  return e.Path + ": " + e.Err.Error()
+}
+
+func (e *FileError) Unwrap() error {
+  return e.Err
+}
+
+// CountFiles counts the words in every file in paths using a pool of
+// workers goroutines, or GOMAXPROCS of them if workers is not positive.
+// Each worker builds its own partial map, and the partial maps are merged
+// once every file has been read.
+//
+// Files that fail to open or read are left out of the counts and reported
+// as a *FileError in the returned error, in the order of paths; the rest
+// are still counted. If ctx is cancelled the counts so far are returned
+// along with ctx.Err().
+func (wc *WordCounter) CountFiles(ctx context.Context, paths []string, workers int) (map[string]int, error) {
+  if workers <= 0 {
+    workers = runtime.GOMAXPROCS(0)
+  }
+
+  jobs := make(chan int)
+  partials := make([]map[string]int, workers)
+  fileErrs := make([]error, len(paths))
+
+  var wg sync.WaitGroup
+  for w := 0; w < workers; w++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+
+      partial := make(map[string]int)
+      for i := range jobs {
+        counts, err := wc.countFile(ctx, paths[i])
+        if err != nil {
+          fileErrs[i] = &FileError{Path: paths[i], Err: err}
+          continue
+        }
+        merge(partial, counts)
+      }
+      partials[w] = partial
+    }()
+  }
+
+feed:
+  for i := range paths {
+    select {
+    case jobs <- i:
+    case <-ctx.Done():
+      break feed
+    }
+  }
+  close(jobs)
+  wg.Wait()
+
+  total := make(map[string]int)
+  for _, partial := range partials {
+    merge(total, partial)
+  }
+
+  return total, errors.Join(append([]error{ctx.Err()}, fileErrs...)...)
+}
+
+// CountDir is like CountFiles for every regular file under root.
+func (wc *WordCounter) CountDir(ctx context.Context, root string, workers int) (map[string]int, error) {
+  var paths []string
+  err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+    if err != nil {
+      return err
+    }
+    if d.Type().IsRegular() {
+      paths = append(paths, path)
+    }
+    return ctx.Err()
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  return wc.CountFiles(ctx, paths, workers)
+}
+
+func (wc *WordCounter) countFile(ctx context.Context, path string) (map[string]int, error) {
+  f, err := os.Open(path)
+  if err != nil {
+    return nil, err
+  }
+  defer f.Close()
+
+  return wc.CountReader(&contextReader{ctx, f})
+}
+
+// contextReader stops reading once its context is done, so cancelling
+// CountFiles doesn't have to wait for large files to be read to the end.
+type contextReader struct {
+  ctx context.Context
+  r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+  if err := r.ctx.Err(); err != nil {
+    return 0, err
+  }
+  return r.r.Read(p)
+}
+
+func merge(dst, src map[string]int) {
+  for word, n := range src {
+    dst[word] += n
+  }
+}
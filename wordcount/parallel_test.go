@@ -0,0 +1,147 @@
+package wordcount
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "io/fs"
+  "maps"
+  "os"
+  "path/filepath"
+  "strings"
+  "testing"
+)
+
+// writeFiles creates n files in dir, each holding size bytes of words that
+// overlap between files, and returns their paths.
+func writeFiles(t testing.TB, dir string, n, size int) []string {
+  t.Helper()
+
+  var paths []string
+  for i := 0; i < n; i++ {
+    var b strings.Builder
+    for j := 0; b.Len() < size; j++ {
+      fmt.Fprintf(&b, "word%d shared%d file%d\n", j%97, j%5, i)
+    }
+
+    path := filepath.Join(dir, fmt.Sprintf("doc%03d.txt", i))
+    if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+      t.Fatal(err)
+    }
+    paths = append(paths, path)
+  }
+  return paths
+}
+
+// sequential counts paths one after another, merging as it goes.
+func sequential(t testing.TB, wc *WordCounter, paths []string) map[string]int {
+  total := make(map[string]int)
+  for _, path := range paths {
+    f, err := os.Open(path)
+    if err != nil {
+      t.Fatal(err)
+    }
+    counts, err := wc.CountReader(f)
+    f.Close()
+    if err != nil {
+      t.Fatal(err)
+    }
+    merge(total, counts)
+  }
+  return total
+}
+
+func TestCountFiles(t *testing.T) {
+  paths := writeFiles(t, t.TempDir(), 20, 4<<10)
+  wc := New()
+  want := sequential(t, wc, paths)
+
+  for _, workers := range []int{0, 1, 3, 50} {
+    got, err := wc.CountFiles(context.Background(), paths, workers)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if !maps.Equal(got, want) {
+      t.Errorf("CountFiles with %d workers differs from sequential count", workers)
+    }
+  }
+}
+
+func TestCountFilesErrors(t *testing.T) {
+  dir := t.TempDir()
+  paths := writeFiles(t, dir, 3, 100)
+  missing := filepath.Join(dir, "missing.txt")
+  paths = append([]string{missing}, paths...)
+
+  got, err := New().CountFiles(context.Background(), paths, 2)
+
+  var fe *FileError
+  if !errors.As(err, &fe) || fe.Path != missing || !errors.Is(err, fs.ErrNotExist) {
+    t.Errorf("err = %v, want a *FileError for %s", err, missing)
+  }
+  if want := sequential(t, New(), paths[1:]); !maps.Equal(got, want) {
+    t.Errorf("counts of the readable files were not returned")
+  }
+}
+
+func TestCountFilesCancel(t *testing.T) {
+  paths := writeFiles(t, t.TempDir(), 10, 1<<10)
+
+  ctx, cancel := context.WithCancel(context.Background())
+  cancel()
+
+  _, err := New().CountFiles(ctx, paths, 2)
+  if !errors.Is(err, context.Canceled) {
+    t.Errorf("err = %v, want %v", err, context.Canceled)
+  }
+}
+
+func TestCountDir(t *testing.T) {
+  dir := t.TempDir()
+  paths := writeFiles(t, dir, 4, 512)
+  sub := filepath.Join(dir, "sub")
+  if err := os.Mkdir(sub, 0o755); err != nil {
+    t.Fatal(err)
+  }
+  paths = append(paths, writeFiles(t, sub, 2, 512)...)
+
+  got, err := New().CountDir(context.Background(), dir, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if want := sequential(t, New(), paths); !maps.Equal(got, want) {
+    t.Errorf("CountDir differs from counting every file")
+  }
+}
+
+func BenchmarkCountFiles(b *testing.B) {
+  paths := writeFiles(b, b.TempDir(), 64, 256<<10)
+  wc := New(WithUnicodeWords(), WithCaseFolding())
+
+  var size int64
+  for _, path := range paths {
+    fi, err := os.Stat(path)
+    if err != nil {
+      b.Fatal(err)
+    }
+    size += fi.Size()
+  }
+
+  b.Run("sequential", func(b *testing.B) {
+    b.SetBytes(size)
+    for i := 0; i < b.N; i++ {
+      sequential(b, wc, paths)
+    }
+  })
+
+  for _, workers := range []int{1, 2, 4, 8} {
+    b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+      b.SetBytes(size)
+      for i := 0; i < b.N; i++ {
+        if _, err := wc.CountFiles(context.Background(), paths, workers); err != nil {
+          b.Fatal(err)
+        }
+      }
+    })
+  }
+}
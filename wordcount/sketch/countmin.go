@@ -0,0 +1,88 @@
+package sketch
+
+import (
+  "errors"
+  "math"
+)
+
+// CountMin is a Count-Min sketch. It estimates how often each key has been
+// added, never underestimating; with probability 1-δ an estimate exceeds
+// the true count by at most ε times the total of all counts.
+type CountMin struct {
+  width  int
+  depth  int
+  counts []uint64
+  total  uint64
+}
+
+// NewCountMin returns a sketch with error bound epsilon and failure
+// probability delta, both in (0, 1). It uses ⌈e/ε⌉·⌈ln(1/δ)⌉ counters.
+func NewCountMin(epsilon, delta float64) *CountMin {
+  if !(epsilon > 0 && epsilon < 1 && delta > 0 && delta < 1) {
+    panic("sketch: NewCountMin epsilon and delta must be in (0, 1)")
+  }
+
+  width := int(math.Ceil(math.E / epsilon))
+  depth := int(math.Ceil(math.Log(1 / delta)))
+  return &CountMin{
+    width:  width,
+    depth:  depth,
+    counts: make([]uint64, width*depth),
+  }
+}
+
+// Add records n more occurrences of key.
+func (s *CountMin) Add(key string, n uint64) {
+  h1, h2 := split(hash(key))
+  for row := 0; row < s.depth; row++ {
+    s.counts[s.index(row, h1, h2)] += n
+  }
+  s.total += n
+}
+
+// Count returns the estimated number of occurrences of key.
+func (s *CountMin) Count(key string) uint64 {
+  h1, h2 := split(hash(key))
+  min := uint64(math.MaxUint64)
+  for row := 0; row < s.depth; row++ {
+    if c := s.counts[s.index(row, h1, h2)]; c < min {
+      min = c
+    }
+  }
+  return min
+}
+
+// Total returns the sum of everything added.
+func (s *CountMin) Total() uint64 {
+  return s.total
+}
+
+// Merge adds the counts of o to s, as if everything added to o had been
+// added to s. Both sketches must have been created with the same bounds.
+func (s *CountMin) Merge(o *CountMin) error {
+  if s.width != o.width || s.depth != o.depth {
+    return errors.New("sketch: merging Count-Min sketches of different sizes")
+  }
+
+  for i, c := range o.counts {
+    s.counts[i] += c
+  }
+  s.total += o.total
+  return nil
+}
+
+// split derives the two hashes used for double hashing from one 64-bit
+// hash. h2 is made odd so that it shares no factor of 2 with an even
+// width: an even h2 could be a multiple of width, and then h1 + row·h2
+// would pick the same column in every row, so a collision in one row
+// would be a collision in all of them.
+func split(h uint64) (uint32, uint32) {
+  return uint32(h), uint32(h>>32) | 1
+}
+
+// index returns the counter for key in row, using h1 + row·h2 as the
+// row's hash function.
+func (s *CountMin) index(row int, h1, h2 uint32) int {
+  h := uint64(h1) + uint64(row)*uint64(h2)
+  return row*s.width + int(h%uint64(s.width))
+}
@@ -0,0 +1,28 @@
+// Package sketch implements probabilistic summaries of streams of strings
+// that use a fixed amount of memory however many distinct strings they
+// see: a Count-Min sketch for frequencies and HyperLogLog for the number
+// of distinct strings.
+package sketch
+
+// hash returns a 64-bit hash of s: FNV-1a followed by the SplitMix64
+// finalizer, which spreads FNV's weak low bits across the whole word.
+// It is deterministic so that sketches built separately can be merged.
+func hash(s string) uint64 {
+  const (
+    offset = 14695981039346656037
+    prime  = 1099511628211
+  )
+
+  h := uint64(offset)
+  for i := 0; i < len(s); i++ {
+    h ^= uint64(s[i])
+    h *= prime
+  }
+
+  h ^= h >> 30
+  h *= 0xbf58476d1ce4e5b9
+  h ^= h >> 27
+  h *= 0x94d049bb133111eb
+  h ^= h >> 31
+  return h
+}
@@ -0,0 +1,95 @@
+package sketch
+
+import (
+  "errors"
+  "math"
+  "math/bits"
+)
+
+// HyperLogLog estimates the number of distinct keys added to it. Its
+// relative standard error is about 1.04/√m, where m is its number of
+// one-byte registers.
+type HyperLogLog struct {
+  p         uint8
+  registers []uint8
+}
+
+// maxPrecision caps a HyperLogLog at 2²⁴ registers, 16 MiB.
+const maxPrecision = 24
+
+// NewHyperLogLog returns an estimator whose relative standard error is at
+// most stdErr, using the smallest power of two registers, at least 2⁴,
+// that achieves it. It panics unless stdErr is in (0, 1) and needs at most
+// 2²⁴ registers, which allows errors down to about 0.00026.
+func NewHyperLogLog(stdErr float64) *HyperLogLog {
+  if !(stdErr > 0 && stdErr < 1) {
+    panic("sketch: NewHyperLogLog stdErr must be in (0, 1)")
+  }
+
+  m := (1.04 / stdErr) * (1.04 / stdErr)
+  p := max(math.Ceil(math.Log2(m)), 4)
+  if p > maxPrecision {
+    panic("sketch: NewHyperLogLog stdErr is too small; it needs more than 2^24 registers")
+  }
+  return &HyperLogLog{p: uint8(p), registers: make([]uint8, 1<<uint8(p))}
+}
+
+// Add records key.
+func (h *HyperLogLog) Add(key string) {
+  x := hash(key)
+  i := x >> (64 - h.p)
+
+  // The rank is the position of the first set bit in the remaining bits.
+  // Or-ing in a sentinel bit caps it when they are all zero.
+  rank := uint8(bits.LeadingZeros64(x<<h.p|1<<(h.p-1)) + 1)
+  if rank > h.registers[i] {
+    h.registers[i] = rank
+  }
+}
+
+// Count returns the estimated number of distinct keys added.
+func (h *HyperLogLog) Count() uint64 {
+  m := float64(len(h.registers))
+
+  sum, zeros := 0.0, 0
+  for _, r := range h.registers {
+    sum += math.Ldexp(1, -int(r))
+    if r == 0 {
+      zeros++
+    }
+  }
+
+  estimate := alpha(m) * m * m / sum
+  if estimate <= 2.5*m && zeros > 0 {
+    // Few registers are set, so linear counting is more accurate.
+    estimate = m * math.Log(m/float64(zeros))
+  }
+  return uint64(estimate + 0.5)
+}
+
+// Merge combines o into h, as if everything added to o had been added to
+// h. Both must have been created with the same error bound.
+func (h *HyperLogLog) Merge(o *HyperLogLog) error {
+  if h.p != o.p {
+    return errors.New("sketch: merging HyperLogLogs of different precision")
+  }
+
+  for i, r := range o.registers {
+    if r > h.registers[i] {
+      h.registers[i] = r
+    }
+  }
+  return nil
+}
+
+func alpha(m float64) float64 {
+  switch m {
+  case 16:
+    return 0.673
+  case 32:
+    return 0.697
+  case 64:
+    return 0.709
+  }
+  return 0.7213 / (1 + 1.079/m)
+}
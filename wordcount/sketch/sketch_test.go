@@ -0,0 +1,147 @@
+package sketch
+
+import (
+  "fmt"
+  "math"
+  "math/rand"
+  "testing"
+)
+
+// zipf returns n keys drawn from a Zipf distribution over distinct keys,
+// roughly the shape of word frequencies in natural text, along with their
+// exact counts.
+func zipf(n, distinct int) ([]string, map[string]uint64) {
+  r := rand.New(rand.NewSource(1))
+  z := rand.NewZipf(r, 1.1, 1, uint64(distinct-1))
+
+  keys := make([]string, n)
+  exact := make(map[string]uint64)
+  for i := range keys {
+    keys[i] = fmt.Sprintf("word%d", z.Uint64())
+    exact[keys[i]]++
+  }
+  return keys, exact
+}
+
+func TestCountMin(t *testing.T) {
+  const epsilon, delta = 0.001, 0.01
+  keys, exact := zipf(200000, 50000)
+
+  s := NewCountMin(epsilon, delta)
+  for _, k := range keys {
+    s.Add(k, 1)
+  }
+
+  if s.Total() != uint64(len(keys)) {
+    t.Errorf("Total = %d, want %d", s.Total(), len(keys))
+  }
+
+  bound := uint64(epsilon * float64(len(keys)))
+  over := 0
+  for k, want := range exact {
+    got := s.Count(k)
+    if got < want {
+      t.Fatalf("Count(%q) = %d, underestimates %d", k, got, want)
+    }
+    if got-want > bound {
+      over++
+    }
+  }
+
+  if rate := float64(over) / float64(len(exact)); rate > delta {
+    t.Errorf("%.2f%% of estimates exceed the error bound, want at most %.2f%%", 100*rate, 100*delta)
+  }
+}
+
+func TestCountMinMerge(t *testing.T) {
+  a, b, both := NewCountMin(0.01, 0.01), NewCountMin(0.01, 0.01), NewCountMin(0.01, 0.01)
+  a.Add("go", 3)
+  b.Add("go", 4)
+  b.Add("gopher", 1)
+  both.Add("go", 7)
+  both.Add("gopher", 1)
+
+  if err := a.Merge(b); err != nil {
+    t.Fatal(err)
+  }
+  if a.Count("go") != both.Count("go") || a.Total() != both.Total() {
+    t.Errorf("merged sketch differs from one built from all the data")
+  }
+
+  if err := a.Merge(NewCountMin(0.1, 0.01)); err == nil {
+    t.Error("merging sketches of different sizes succeeded")
+  }
+}
+
+func TestHyperLogLog(t *testing.T) {
+  for _, stdErr := range []float64{0.05, 0.02, 0.01} {
+    for _, n := range []int{0, 10, 1000, 100000} {
+      h := NewHyperLogLog(stdErr)
+      for i := 0; i < n; i++ {
+        key := fmt.Sprintf("key%d", i)
+        h.Add(key)
+        h.Add(key) // duplicates must not count
+      }
+
+      got := float64(h.Count())
+      // Four standard errors keeps the test from flaking while still
+      // catching an estimator that is wrong rather than unlucky.
+      if err := math.Abs(got - float64(n)); err > 4*stdErr*float64(n)+1 {
+        t.Errorf("stdErr %v: Count = %v for %d distinct keys", stdErr, got, n)
+      }
+    }
+  }
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+  a, b := NewHyperLogLog(0.01), NewHyperLogLog(0.01)
+  for i := 0; i < 5000; i++ {
+    a.Add(fmt.Sprint(i))
+    b.Add(fmt.Sprint(i + 2500))
+  }
+  if err := a.Merge(b); err != nil {
+    t.Fatal(err)
+  }
+
+  if got := float64(a.Count()); math.Abs(got-7500) > 4*0.01*7500 {
+    t.Errorf("merged Count = %v, want about 7500", got)
+  }
+  if err := a.Merge(NewHyperLogLog(0.1)); err == nil {
+    t.Error("merging HyperLogLogs of different precision succeeded")
+  }
+}
+
+func TestNewCountMinBounds(t *testing.T) {
+  for _, tt := range []struct{ epsilon, delta float64 }{
+    {0, 0.01}, {1, 0.01}, {0.01, 0}, {0.01, 1}, {math.NaN(), 0.01}, {0.01, math.NaN()},
+  } {
+    func() {
+      defer func() {
+        if recover() == nil {
+          t.Errorf("NewCountMin(%g, %g) did not panic", tt.epsilon, tt.delta)
+        }
+      }()
+      NewCountMin(tt.epsilon, tt.delta)
+    }()
+  }
+}
+
+func TestNewHyperLogLogBounds(t *testing.T) {
+  for _, stdErr := range []float64{0.1, 0.01, 0.002, 0.001, 0.0003} {
+    h := NewHyperLogLog(stdErr)
+    if got := 1.04 / math.Sqrt(float64(len(h.registers))); got > stdErr {
+      t.Errorf("NewHyperLogLog(%g) has %d registers, for an error of %g", stdErr, len(h.registers), got)
+    }
+  }
+
+  for _, stdErr := range []float64{0, 1, 0.0002, math.NaN()} {
+    func() {
+      defer func() {
+        if recover() == nil {
+          t.Errorf("NewHyperLogLog(%g) did not panic", stdErr)
+        }
+      }()
+      NewHyperLogLog(stdErr)
+    }()
+  }
+}
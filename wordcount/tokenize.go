@@ -0,0 +1,91 @@
+package wordcount
+
+import (
+  "strings"
+  "unicode"
+  "unicode/utf8"
+)
+
+// ScanUnicodeWords is a bufio.SplitFunc that returns runs of letters,
+// digits and combining marks, dropping whitespace and punctuation around
+// them. An apostrophe or hyphen between two word characters is kept, so
+// "don't" and "well-known" are single words. It approximates the Unicode
+// word boundary rules closely enough for counting.
+func ScanUnicodeWords(data []byte, atEOF bool) (advance int, token []byte, err error) {
+  // Skip anything that can't start a word.
+  start := 0
+  for start < len(data) {
+    if !atEOF && !utf8.FullRune(data[start:]) {
+      return start, nil, nil
+    }
+
+    r, width := utf8.DecodeRune(data[start:])
+    if isWordRune(r) {
+      break
+    }
+    start += width
+  }
+
+  for i := start; i < len(data); {
+    if !atEOF && !utf8.FullRune(data[i:]) {
+      return start, nil, nil
+    }
+
+    r, width := utf8.DecodeRune(data[i:])
+    if isWordRune(r) {
+      i += width
+      continue
+    }
+
+    if isJoiner(r) {
+      next := data[i+width:]
+      if !atEOF && !utf8.FullRune(next) {
+        return start, nil, nil
+      }
+      if n, _ := utf8.DecodeRune(next); len(next) > 0 && isWordRune(n) {
+        i += width
+        continue
+      }
+    }
+    return i + width, data[start:i], nil
+  }
+
+  if atEOF && start < len(data) {
+    return len(data), data[start:], nil
+  }
+  return start, nil, nil
+}
+
+func isWordRune(r rune) bool {
+  return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_'
+}
+
+func isJoiner(r rune) bool {
+  return r == '\'' || r == '’' || r == '-'
+}
+
+// StripPunctuation trims leading and trailing Unicode punctuation from
+// word, so "Go," and "(Go)" both become "Go".
+func StripPunctuation(word string) string {
+  return strings.TrimFunc(word, unicode.IsPunct)
+}
+
+// FoldCase maps word to a caseless form so that words differing only in
+// case compare equal. Unlike strings.ToLower it also unifies characters
+// such as final sigma "ς" with "σ" by going through upper case first.
+// Scripts without case, like "世界", are left as they are.
+func FoldCase(word string) string {
+  return strings.Map(func(r rune) rune {
+    return unicode.ToLower(unicode.ToUpper(r))
+  }, word)
+}
+
+// EnglishStopWords is a short list of common English words that carry
+// little meaning on their own, for use with WithStopWords.
+var EnglishStopWords = []string{
+  "a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "from",
+  "has", "have", "he", "her", "his", "i", "if", "in", "is", "it", "its",
+  "of", "on", "or", "she", "so", "that", "the", "their", "them", "then",
+  "there", "they", "this", "to", "was", "we", "were", "will", "with",
+  "you",
+}
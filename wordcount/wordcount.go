@@ -0,0 +1,18 @@
+package wordcount
+
+import "io"
+
+var plain = New()
+
+// WordCount returns a map of the counts of each "word" in the string s,
+// where words are separated by whitespace.
+func WordCount(s string) map[string]int {
+  return plain.Count(s)
+}
+
+// WordCountReader is like WordCount but reads its input from r a buffer at
+// a time, so the input never has to fit in memory, only its distinct words.
+// Words longer than bufio.MaxScanTokenSize fail with bufio.ErrTooLong.
+func WordCountReader(r io.Reader) (map[string]int, error) {
+  return plain.CountReader(r)
+}
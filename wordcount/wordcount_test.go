@@ -0,0 +1,88 @@
+package wordcount
+
+import (
+  "bufio"
+  "errors"
+  "maps"
+  "strings"
+  "testing"
+  "testing/iotest"
+)
+
+var wordCountTests = []struct {
+  in   string
+  want map[string]int
+}{
+  {"", map[string]int{}},
+  {"   \n\t ", map[string]int{}},
+  {"I am learning Go!", map[string]int{
+    "I": 1, "am": 1, "learning": 1, "Go!": 1,
+  }},
+  {"I ate a donut. Then I ate another donut.", map[string]int{
+    "I": 2, "ate": 2, "a": 1, "donut.": 2, "Then": 1, "another": 1,
+  }},
+  {"  leading and\ttrailing\r\nwhitespace  ", map[string]int{
+    "leading": 1, "and": 1, "trailing": 1, "whitespace": 1,
+  }},
+  {"Hello 世界　世界", map[string]int{
+    "Hello": 1, "世界": 2,
+  }},
+}
+
+func TestWordCount(t *testing.T) {
+  for _, tt := range wordCountTests {
+    if got := WordCount(tt.in); !maps.Equal(got, tt.want) {
+      t.Errorf("WordCount(%q) = %v, want %v", tt.in, got, tt.want)
+    }
+  }
+}
+
+func TestWordCountReader(t *testing.T) {
+  for _, tt := range wordCountTests {
+    // Reading one byte at a time makes sure words split across reads are
+    // stitched back together.
+    got, err := WordCountReader(iotest.OneByteReader(strings.NewReader(tt.in)))
+    if err != nil {
+      t.Errorf("WordCountReader(%q): %v", tt.in, err)
+    }
+    if !maps.Equal(got, tt.want) {
+      t.Errorf("WordCountReader(%q) = %v, want %v", tt.in, got, tt.want)
+    }
+  }
+}
+
+func TestWordCountReaderError(t *testing.T) {
+  errBoom := errors.New("boom")
+  if _, err := WordCountReader(iotest.ErrReader(errBoom)); !errors.Is(err, errBoom) {
+    t.Errorf("err = %v, want %v", err, errBoom)
+  }
+
+  // Data returned alongside io.EOF still counts.
+  got, err := WordCountReader(iotest.DataErrReader(strings.NewReader("partial read")))
+  if err != nil || got["partial"] != 1 || got["read"] != 1 {
+    t.Errorf("WordCountReader = %v, %v; want both words counted", got, err)
+  }
+}
+
+func TestLongWord(t *testing.T) {
+  long := strings.Repeat("x", bufio.MaxScanTokenSize+1)
+
+  if got := WordCount(long + " y"); got[long] != 1 || got["y"] != 1 {
+    t.Errorf("WordCount dropped a long word")
+  }
+
+  if _, err := WordCountReader(strings.NewReader(long)); !errors.Is(err, bufio.ErrTooLong) {
+    t.Errorf("WordCountReader(long word) error = %v, want %v", err, bufio.ErrTooLong)
+  }
+}
+
+func BenchmarkWordCountReader(b *testing.B) {
+  text := strings.Repeat("the quick brown fox jumps over the lazy dog\n", 1<<14)
+  b.SetBytes(int64(len(text)))
+
+  for i := 0; i < b.N; i++ {
+    if _, err := WordCountReader(strings.NewReader(text)); err != nil {
+      b.Fatal(err)
+    }
+  }
+}
@@ -0,0 +1,77 @@
+package wordfreq
+
+import (
+  "encoding/csv"
+  "encoding/json"
+  "fmt"
+  "io"
+  "strconv"
+  "text/tabwriter"
+)
+
+// Report summarizes a set of word counts.
+type Report struct {
+  Total    int     `json:"total"`
+  Distinct int     `json:"distinct"`
+  Top      []Entry `json:"top"`
+}
+
+// NewReport builds a report of the k most frequent words in counts. A k of
+// 0 or less includes every word.
+func NewReport(counts map[string]int, k int) Report {
+  return Report{
+    Total:    Total(counts),
+    Distinct: len(counts),
+    Top:      TopK(counts, k),
+  }
+}
+
+// WriteText writes r as aligned columns of rank, count, frequency and word,
+// followed by the totals.
+func (r Report) WriteText(w io.Writer) error {
+  tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+  fmt.Fprintln(tw, "rank\tcount\tfreq\tword")
+  for i, e := range r.Top {
+    fmt.Fprintf(tw, "%d\t%d\t%.4f\t%s\n", i+1, e.Count, e.Freq, e.Word)
+  }
+  if err := tw.Flush(); err != nil {
+    return err
+  }
+
+  _, err := fmt.Fprintf(w, "%d words, %d distinct\n", r.Total, r.Distinct)
+  return err
+}
+
+// WriteCSV writes r's entries as CSV with a word,count,freq header row.
+func (r Report) WriteCSV(w io.Writer) error {
+  cw := csv.NewWriter(w)
+  cw.Write([]string{"word", "count", "freq"})
+  for _, e := range r.Top {
+    cw.Write([]string{e.Word, strconv.Itoa(e.Count), strconv.FormatFloat(e.Freq, 'g', -1, 64)})
+  }
+  cw.Flush()
+  return cw.Error()
+}
+
+// WriteJSON writes r as an indented JSON object.
+func (r Report) WriteJSON(w io.Writer) error {
+  enc := json.NewEncoder(w)
+  enc.SetIndent("", "  ")
+  return enc.Encode(r)
+}
+
+// Formats lists the formats Write accepts.
+var Formats = []string{"text", "csv", "json"}
+
+// Write writes r in the named format, one of Formats.
+func (r Report) Write(w io.Writer, format string) error {
+  switch format {
+  case "text":
+    return r.WriteText(w)
+  case "csv":
+    return r.WriteCSV(w)
+  case "json":
+    return r.WriteJSON(w)
+  }
+  return fmt.Errorf("wordfreq: unknown report format %q", format)
+}
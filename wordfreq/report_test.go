@@ -0,0 +1,58 @@
+package wordfreq
+
+import (
+  "strings"
+  "testing"
+)
+
+var reportCounts = map[string]int{"gopher": 1, "go": 3}
+
+func TestReportFormats(t *testing.T) {
+  tests := []struct {
+    format string
+    want   string
+  }{
+    {"text", "" +
+      "rank  count  freq    word\n" +
+      "1     3      0.7500  go\n" +
+      "2     1      0.2500  gopher\n" +
+      "4 words, 2 distinct\n"},
+    {"csv", "" +
+      "word,count,freq\n" +
+      "go,3,0.75\n" +
+      "gopher,1,0.25\n"},
+    {"json", `{
+  "total": 4,
+  "distinct": 2,
+  "top": [
+    {
+      "word": "go",
+      "count": 3,
+      "freq": 0.75
+    },
+    {
+      "word": "gopher",
+      "count": 1,
+      "freq": 0.25
+    }
+  ]
+}
+`},
+  }
+
+  for _, tt := range tests {
+    var b strings.Builder
+    if err := NewReport(reportCounts, 0).Write(&b, tt.format); err != nil {
+      t.Fatal(err)
+    }
+    if b.String() != tt.want {
+      t.Errorf("%s report =\n%s\nwant:\n%s", tt.format, b.String(), tt.want)
+    }
+  }
+}
+
+func TestReportUnknownFormat(t *testing.T) {
+  if err := NewReport(reportCounts, 0).Write(new(strings.Builder), "xml"); err == nil {
+    t.Error("Write with unknown format succeeded")
+  }
+}
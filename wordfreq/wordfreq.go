@@ -0,0 +1,91 @@
+// Package wordfreq answers frequency questions about the word counts
+// produced by the wordcount package: the most common words, their share
+// of the total, and reports of both as text, CSV or JSON.
+package wordfreq
+
+import (
+  "container/heap"
+  "sort"
+)
+
+// Entry is a word together with its count and its share of all words.
+type Entry struct {
+  Word  string  `json:"word"`
+  Count int     `json:"count"`
+  Freq  float64 `json:"freq"`
+}
+
+// Total returns the sum of all counts.
+func Total(counts map[string]int) int {
+  total := 0
+  for _, n := range counts {
+    total += n
+  }
+  return total
+}
+
+// Relative returns each word's count divided by the total of all counts.
+func Relative(counts map[string]int) map[string]float64 {
+  total := Total(counts)
+  freqs := make(map[string]float64, len(counts))
+  for word, n := range counts {
+    freqs[word] = float64(n) / float64(total)
+  }
+  return freqs
+}
+
+// TopK returns the k most frequent words, most frequent first, with ties
+// broken alphabetically so the result is deterministic. A k of 0 or less
+// returns every word.
+//
+// Only k entries are kept in a min-heap while scanning counts, so finding
+// the top few of millions of distinct words stays cheap.
+func TopK(counts map[string]int, k int) []Entry {
+  if k <= 0 || k > len(counts) {
+    k = len(counts)
+  }
+  total := float64(Total(counts))
+
+  h := make(entryHeap, 0, k)
+  for word, n := range counts {
+    e := Entry{Word: word, Count: n}
+    switch {
+    case len(h) < k:
+      heap.Push(&h, e)
+    case k > 0 && less(h[0], e):
+      h[0] = e
+      heap.Fix(&h, 0)
+    }
+  }
+
+  top := []Entry(h)
+  sort.Slice(top, func(i, j int) bool { return less(top[j], top[i]) })
+  for i := range top {
+    top[i].Freq = float64(top[i].Count) / total
+  }
+  return top
+}
+
+// less reports whether a ranks below b.
+func less(a, b Entry) bool {
+  if a.Count != b.Count {
+    return a.Count < b.Count
+  }
+  return a.Word > b.Word
+}
+
+// entryHeap is a min-heap of entries ordered by rank, so the lowest ranked
+// of the current top k is always at the root.
+type entryHeap []Entry
+
+func (h entryHeap) Len() int           { return len(h) }
+func (h entryHeap) Less(i, j int) bool { return less(h[i], h[j]) }
+func (h entryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *entryHeap) Push(x any)        { *h = append(*h, x.(Entry)) }
+
+func (h *entryHeap) Pop() any {
+  old := *h
+  e := old[len(old)-1]
+  *h = old[:len(old)-1]
+  return e
+}
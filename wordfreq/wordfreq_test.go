@@ -0,0 +1,79 @@
+package wordfreq
+
+import (
+  "fmt"
+  "math"
+  "math/rand"
+  "reflect"
+  "sort"
+  "testing"
+)
+
+func TestTopK(t *testing.T) {
+  counts := map[string]int{"go": 3, "the": 5, "a": 3, "gopher": 1, "zebra": 3}
+
+  got := TopK(counts, 3)
+  want := []Entry{
+    {"the", 5, 5.0 / 15},
+    {"a", 3, 3.0 / 15},
+    {"go", 3, 3.0 / 15},
+  }
+  if !reflect.DeepEqual(got, want) {
+    t.Errorf("TopK(3) = %v, want %v", got, want)
+  }
+
+  if got := TopK(counts, 0); len(got) != len(counts) || got[4].Word != "gopher" {
+    t.Errorf("TopK(0) = %v, want all %d words", got, len(counts))
+  }
+  if got := TopK(nil, 5); len(got) != 0 {
+    t.Errorf("TopK(nil) = %v, want none", got)
+  }
+}
+
+// TestTopKMatchesSort checks the heap against sorting every entry.
+func TestTopKMatchesSort(t *testing.T) {
+  r := rand.New(rand.NewSource(1))
+  counts := make(map[string]int)
+  for i := 0; i < 5000; i++ {
+    counts[fmt.Sprintf("w%d", r.Intn(2000))] += r.Intn(20) + 1
+  }
+
+  all := make([]Entry, 0, len(counts))
+  for word, n := range counts {
+    all = append(all, Entry{Word: word, Count: n, Freq: float64(n) / float64(Total(counts))})
+  }
+  sort.Slice(all, func(i, j int) bool { return less(all[j], all[i]) })
+
+  for _, k := range []int{1, 10, 100, len(all)} {
+    if got := TopK(counts, k); !reflect.DeepEqual(got, all[:k]) {
+      t.Errorf("TopK(%d) differs from sorting", k)
+    }
+  }
+}
+
+func TestRelative(t *testing.T) {
+  freqs := Relative(map[string]int{"a": 1, "b": 3})
+  if freqs["a"] != 0.25 || freqs["b"] != 0.75 {
+    t.Errorf("Relative = %v, want a:0.25 b:0.75", freqs)
+  }
+
+  sum := 0.0
+  for _, f := range Relative(map[string]int{"x": 7, "y": 11, "z": 13}) {
+    sum += f
+  }
+  if math.Abs(sum-1) > 1e-15 {
+    t.Errorf("relative frequencies sum to %v, want 1", sum)
+  }
+}
+
+func BenchmarkTopK(b *testing.B) {
+  counts := make(map[string]int)
+  for i := 0; i < 1e6; i++ {
+    counts[fmt.Sprintf("w%d", i)] = i % 1000
+  }
+  b.ResetTimer()
+
+  for i := 0; i < b.N; i++ {
+    TopK(counts, 10)
+  }
+}